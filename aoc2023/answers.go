@@ -0,0 +1,121 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Verification statuses reported when comparing a Result against the answers file
+const (
+	verifyPass    = "PASS"
+	verifyFail    = "FAIL"
+	verifyMissing = "MISSING"
+)
+
+// A single accepted answer in the answers file
+type answerEntry struct {
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	File   string `json:"file"`
+	Answer int    `json:"answer"`
+}
+
+// Answers are keyed by day, part, and input file name (without directories)
+type answerKey struct {
+	day  int
+	part int
+	file string
+}
+
+/*
+Collection of accepted answers loaded from (and saved back to) a local JSON file.
+Supports verifying a Result against a stored answer and recording new answers.
+*/
+type answerBook struct {
+	path    string
+	answers map[answerKey]int
+}
+
+// Loads the answers file at path. A missing file is treated as an empty collection
+// so the first recorded answer creates it.
+func loadAnswers(path string) (*answerBook, error) {
+	book := &answerBook{path: path, answers: make(map[answerKey]int)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]answerEntry, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing answers file %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		book.answers[newAnswerKey(entry.Day, entry.Part, entry.File)] = entry.Answer
+	}
+	return book, nil
+}
+
+// Helper function creates an answerKey, ignoring the directory portion of the file path
+func newAnswerKey(day int, part int, inputPath string) answerKey {
+	return answerKey{day: day, part: part, file: filepath.Base(inputPath)}
+}
+
+// Compares a Result against the stored answer for the input file. Returns the
+// verification status and a human readable detail string.
+func (b *answerBook) verify(result *utility.Result, inputPath string) (string, string) {
+	expected, found := b.answers[newAnswerKey(result.Day, result.Part, inputPath)]
+	if !found {
+		return verifyMissing, "no stored answer for " + filepath.Base(inputPath)
+	}
+	if expected != result.Answer {
+		return verifyFail, "expected " + strconv.Itoa(expected) + ", got " +
+			strconv.Itoa(result.Answer)
+	}
+	return verifyPass, "matches " + strconv.Itoa(expected)
+}
+
+// Stores the Result answer for the input file, replacing any previous answer
+func (b *answerBook) record(result *utility.Result, inputPath string) {
+	b.answers[newAnswerKey(result.Day, result.Part, inputPath)] = result.Answer
+}
+
+// Writes the answers back to the answers file ordered by day, part, then file name
+func (b *answerBook) save() error {
+	entries := make([]answerEntry, 0, len(b.answers))
+	for key, answer := range b.answers {
+		entries = append(entries, answerEntry{
+			Day:    key.day,
+			Part:   key.part,
+			File:   key.file,
+			Answer: answer,
+		})
+	}
+	slices.SortFunc(entries, func(a, b answerEntry) int {
+		if a.Day != b.Day {
+			return cmp.Compare(a.Day, b.Day)
+		}
+		if a.Part != b.Part {
+			return cmp.Compare(a.Part, b.Part)
+		}
+		return cmp.Compare(a.File, b.File)
+	})
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.path, append(data, '\n'), 0644)
+}
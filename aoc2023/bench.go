@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+Data structure for benchmark statistics gathered by running a Solver repeatedly
+against the same input. Durations are serialized as nanoseconds.
+*/
+type benchStats struct {
+	Day         int           `json:"day"`
+	Part        int           `json:"part"`
+	File        string        `json:"file"`
+	Runs        int           `json:"runs"`
+	Answer      int           `json:"answer"`
+	Min         time.Duration `json:"min_ns"`
+	Median      time.Duration `json:"median_ns"`
+	P95         time.Duration `json:"p95_ns"`
+	Max         time.Duration `json:"max_ns"`
+	AllocsPerOp uint64        `json:"allocs_per_op"`
+	BytesPerOp  uint64        `json:"bytes_per_op"`
+}
+
+// Runs the Solver part against the input runs times and gathers wall time and
+// allocation statistics
+func runBench(solver utility.Solver, input *[]string, part int, runs int,
+	filepath string) (*benchStats, error) {
+	if runs < 1 {
+		return nil, fmt.Errorf("bench runs must be at least 1, got %d", runs)
+	}
+
+	// time each run and track allocations across all runs
+	durations := make([]time.Duration, runs)
+	var result *utility.Result
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+		var err error
+		result, err = solver.Solve(input, part)
+		durations[i] = time.Since(start)
+		if err != nil {
+			return nil, err
+		}
+	}
+	runtime.ReadMemStats(&after)
+
+	slices.Sort(durations)
+	stats := &benchStats{
+		Day:         solver.Day(),
+		Part:        part,
+		File:        filepath,
+		Runs:        runs,
+		Answer:      result.Answer,
+		Min:         durations[0],
+		Median:      percentile(durations, 0.5),
+		P95:         percentile(durations, 0.95),
+		Max:         durations[runs-1],
+		AllocsPerOp: (after.Mallocs - before.Mallocs) / uint64(runs),
+		BytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / uint64(runs),
+	}
+	return stats, nil
+}
+
+// Nearest-rank percentile of a sorted list of durations, ex. p=0.95 for p95
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Writes benchmark statistics for every file and part as a single indented JSON array
+func writeBenchStats(allStats []*benchStats) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(allStats)
+}
+
+// Prints benchmark statistics as human readable text or a single line JSON object
+// (ndjson). json output is written as one array by benchJobs()
+func printBenchStats(stats *benchStats, format string) error {
+	if format == outputNdjson {
+		return json.NewEncoder(os.Stdout).Encode(stats)
+	}
+	fmt.Println("--- Benchmarked Day", stats.Day, "- Part", stats.Part, "---")
+	fmt.Println("file:", stats.File, "runs:", stats.Runs, "answer:", stats.Answer)
+	fmt.Printf("min: %s  median: %s  p95: %s  max: %s\n", stats.Min, stats.Median,
+		stats.P95, stats.Max)
+	fmt.Printf("allocs/op: %d  bytes/op: %d\n", stats.AllocsPerOp, stats.BytesPerOp)
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Benchmarks every registered day and part against its default (real) input, ex.
+// go test -bench=Solvers/4.2 -benchmem
+func BenchmarkSolvers(b *testing.B) {
+	for _, solver := range utility.Solvers() {
+		for _, part := range solver.Parts() {
+			key := dayPartKey(solver.Day(), part)
+			filepath, err := resolveInputPath(solver.Day(), part, inputReal)
+			if err != nil {
+				b.Fatal(err)
+			}
+			input, err := readInputFile(&filepath)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.Run(key, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := solver.Solve(input, part); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// Benchmarks every day and part against its example input(s)
+func BenchmarkExamples(b *testing.B) {
+	_, keys := exampleAnswers(b)
+	for _, key := range keys {
+		solver, err := utility.LookupSolver(key.day)
+		if err != nil {
+			b.Fatal(err)
+		}
+		inputPath := "data/" + key.file
+		input, err := readInputFile(&inputPath)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(dayPartKey(key.day, key.part), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := solver.Solve(input, key.part); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package day_five
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Seed ids per chunk of brute force work. Small enough for steady progress reporting and
+// quick cancellation, big enough that channel traffic doesn't matter.
+const bruteForceChunkSize = 1 << 20
+
+// Seeds translated between context cancellation checks
+const bruteForceCheckEvery = 1 << 14
+
+/*
+Settings for the parallel brute force search: number of worker goroutines, seed ids per
+chunk of work, and an optional progress callback called as chunks finish
+*/
+type BruteForceOptions struct {
+	Workers   int
+	ChunkSize int
+	Progress  func(done int, total int)
+}
+
+// Reference implementation for part 2: translates every seed id in every seed range one
+// at a time with translateSeed() and returns the lowest location id. Seed ranges are split
+// into chunks that a pool of workers processes in parallel, each keeping a local minimum
+// instead of materializing id slices. The answer doesn't depend on scheduling. Returns
+// ctx.Err() if ctx is cancelled before every chunk is done.
+func BruteForceLowestLocation(ctx context.Context, seedIdRanges *[][]int,
+	translators *[]Translator, opts BruteForceOptions) (int, error) {
+	workers := max(opts.Workers, 1)
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = bruteForceChunkSize
+	}
+	chunks := splitSeedIdRanges(seedIdRanges, chunkSize)
+
+	// workers stop on their own when ctx is cancelled
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// feed chunks to the workers
+	chunkQueue := make(chan []int)
+	go func() {
+		defer close(chunkQueue)
+		for _, chunk := range chunks {
+			select {
+			case chunkQueue <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// each worker sends back one local minimum per finished chunk
+	localMins := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunkQueue {
+				locId, ok := minLocFromSeedIdRange(ctx, chunk, translators)
+				if !ok {
+					return
+				}
+				localMins <- locId
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(localMins)
+	}()
+
+	// collect local minimums - min() is order independent, so results are deterministic
+	minLocId := math.MaxInt
+	done := 0
+	for locId := range localMins {
+		minLocId = min(minLocId, locId)
+		done++
+		if opts.Progress != nil {
+			opts.Progress(done, len(chunks))
+		}
+	}
+	if done < len(chunks) {
+		return 0, ctx.Err()
+	}
+	return minLocId, nil
+}
+
+// Lowest location id for every seed in a [start, end) range, translated one at a time.
+// Returns false if ctx was cancelled part way through.
+func minLocFromSeedIdRange(ctx context.Context, seedIdRange []int,
+	translators *[]Translator) (int, bool) {
+	minLocId := math.MaxInt
+	for seedId := seedIdRange[0]; seedId < seedIdRange[1]; seedId++ {
+		// checking ctx on every seed would dominate the work - check every so often
+		if (seedId-seedIdRange[0])%bruteForceCheckEvery == 0 && ctx.Err() != nil {
+			return 0, false
+		}
+		minLocId = min(minLocId, translateSeed(seedId, translators))
+	}
+	return minLocId, true
+}
+
+// Splits [start, end) seed ranges into chunks of at most chunkSize seed ids
+func splitSeedIdRanges(seedIdRanges *[][]int, chunkSize int) [][]int {
+	chunks := make([][]int, 0)
+	for _, seedRange := range *seedIdRanges {
+		for start := seedRange[0]; start < seedRange[1]; start += chunkSize {
+			chunks = append(chunks, []int{start, min(start+chunkSize, seedRange[1])})
+		}
+	}
+	return chunks
+}
+
+// Reference implementation for day 5 part 2 - parses the input and brute
+// forces the lowest location id with a worker pool
+func referencePartTwo(ctx context.Context, input *[]string,
+	opts utility.ReferenceOptions) (*utility.Result, error) {
+	puzzle, err := ParseDayFive(input)
+	if err != nil {
+		return nil, err
+	}
+	parsed := puzzle.(*almanac)
+	if err := checkSeedIdPairs(parsed.seedIds); err != nil {
+		return nil, err
+	}
+
+	minLocId, err := BruteForceLowestLocation(ctx, parseSeedIdRanges(parsed.seedIds),
+		parsed.translators, BruteForceOptions{Workers: opts.Workers, Progress: opts.Progress})
+	if err != nil {
+		return nil, err
+	}
+	return utility.NewResult(5, 2, "Lowest Location Id for seeds ranges (brute force)",
+		minLocId), nil
+}
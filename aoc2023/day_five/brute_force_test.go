@@ -0,0 +1,68 @@
+package day_five
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// Brute force should match the interval answer however the work is split up
+func TestBruteForceLowestLocation(t *testing.T) {
+	input := readInput(t, "../data/day_five_ex.txt")
+	puzzle, err := ParseDayFive(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := puzzle.(*almanac)
+	seedIdRanges := parseSeedIdRanges(parsed.seedIds)
+	want := rangedLowestLocation(parsed.seedIds, parsed.translators)
+
+	for _, opts := range []BruteForceOptions{{Workers: 1}, {Workers: 3, ChunkSize: 4}} {
+		progressCalls, lastDone, lastTotal := 0, 0, 0
+		opts.Progress = func(done int, total int) {
+			progressCalls++
+			lastDone, lastTotal = done, total
+		}
+		got, err := BruteForceLowestLocation(context.Background(), seedIdRanges,
+			parsed.translators, opts)
+		if err != nil || got != want {
+			t.Errorf("%+v: brute force = %d, %v, want %d", opts, got, err, want)
+		}
+		chunkSize := opts.ChunkSize
+		if chunkSize == 0 {
+			chunkSize = bruteForceChunkSize
+		}
+		chunks := len(splitSeedIdRanges(seedIdRanges, chunkSize))
+		if progressCalls != chunks || lastDone != chunks || lastTotal != chunks {
+			t.Errorf("%+v: progress called %d times ending at %d/%d, want %d chunks", opts,
+				progressCalls, lastDone, lastTotal, chunks)
+		}
+	}
+}
+
+// Cancelling the context stops the workers part way through and returns ctx.Err()
+func TestBruteForceCancel(t *testing.T) {
+	input := readInput(t, "../data/day_five_ex.txt")
+	puzzle, err := ParseDayFive(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	translators := puzzle.(*almanac).translators
+	// ~4*10^9 seeds - far too many to finish before the cancel lands
+	seedIdRanges := [][]int{{0, 1 << 32}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := BruteForceOptions{Workers: 2, ChunkSize: 1 << 16, Progress: func(done int, total int) {
+		if done == 2 {
+			cancel()
+		}
+	}}
+	if _, err := BruteForceLowestLocation(ctx, &seedIdRanges, translators, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled brute force error = %v, want context.Canceled", err)
+	}
+
+	// already cancelled before starting
+	if _, err := BruteForceLowestLocation(ctx, &seedIdRanges, translators, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("pre-cancelled brute force error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,124 @@
+package day_five
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+Graph of almanac categories (seed, soil, fertilizer, ...) where each Translator is an
+edge from its source category to its destination category. Maps can appear in any order
+and a category can branch to several destinations. Translation between two categories
+follows the shortest chain of maps between them.
+*/
+type CategoryGraph struct {
+	edges map[string]map[string]*Translator
+}
+
+// Constructor builds the graph from parsed Translators. Returns a ParseError for a map
+// without "<<source>>-to-<<dest>>" names or a second map between the same categories.
+func NewCategoryGraph(translators *[]Translator) (*CategoryGraph, error) {
+	edges := make(map[string]map[string]*Translator)
+	for i := range *translators {
+		translator := &(*translators)[i]
+		source := translator.Source()
+		dest := translator.Destination()
+		if len(source) == 0 || len(dest) == 0 {
+			return nil, utility.NewParseError(translator.line, 0,
+				fmt.Sprintf("map %q isn't named \"<<source>>-to-<<dest>>\"",
+					translator.TranslatorType), nil)
+		}
+		if edges[source] == nil {
+			edges[source] = make(map[string]*Translator)
+		}
+		if existing, ok := edges[source][dest]; ok {
+			return nil, utility.NewParseError(translator.line, 0,
+				fmt.Sprintf("duplicate %s map (first defined on line %d)",
+					translator.TranslatorType, existing.line), nil)
+		}
+		edges[source][dest] = translator
+	}
+	return &CategoryGraph{edges: edges}, nil
+}
+
+// Returns every category name in the graph, sorted
+func (g *CategoryGraph) Categories() []string {
+	categories := make([]string, 0)
+	for source, dests := range g.edges {
+		categories = append(categories, source)
+		for dest := range dests {
+			categories = append(categories, dest)
+		}
+	}
+	slices.Sort(categories)
+	return slices.Compact(categories)
+}
+
+// Finds the shortest ordered chain of Translators from one category to another with a
+// breadth first search. Returns an error when either category is unknown or to can't be
+// reached from from. Translating a category to itself is an empty chain.
+func (g *CategoryGraph) Path(from string, to string) (*[]Translator, error) {
+	categories := g.Categories()
+	for _, category := range []string{from, to} {
+		if !slices.Contains(categories, category) {
+			return nil, fmt.Errorf("unknown category %q: almanac has %v", category, categories)
+		}
+	}
+
+	// breadth first search - remember the map used to reach each category
+	reachedBy := map[string]*Translator{from: nil}
+	queue := []string{from}
+	for len(queue) > 0 && reachedBy[to] == nil && from != to {
+		category := queue[0]
+		queue = queue[1:]
+		// visit destinations in sorted order so branches resolve the same way every run
+		dests := make([]string, 0, len(g.edges[category]))
+		for dest := range g.edges[category] {
+			dests = append(dests, dest)
+		}
+		slices.Sort(dests)
+		for _, dest := range dests {
+			if _, seen := reachedBy[dest]; !seen {
+				reachedBy[dest] = g.edges[category][dest]
+				queue = append(queue, dest)
+			}
+		}
+	}
+	if _, reached := reachedBy[to]; !reached {
+		return nil, fmt.Errorf("no chain of maps from %q to %q", from, to)
+	}
+
+	// walk back from the target to build the chain in order
+	path := make([]Translator, 0)
+	for category := to; category != from; {
+		translator := reachedBy[category]
+		path = append(path, *translator)
+		category = translator.Source()
+	}
+	slices.Reverse(path)
+	return &path, nil
+}
+
+// Translates an id from one category to another, ex. a soil id to a humidity id
+func (g *CategoryGraph) Translate(id int, from string, to string) (int, error) {
+	path, err := g.Path(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return translateSeed(id, path), nil
+}
+
+// Composes the chain of maps between two categories into a single PiecewiseMapping
+func (g *CategoryGraph) Mapping(from string, to string) (*PiecewiseMapping, error) {
+	path, err := g.Path(from, to)
+	if err != nil {
+		return nil, err
+	}
+	mapping := ComposeChain(path)
+	if len(*path) == 0 {
+		mapping.Name = from + "-to-" + to
+	}
+	return mapping, nil
+}
@@ -0,0 +1,133 @@
+package day_five
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Splits the example almanac into its seeds line and one block of lines per map
+func exampleBlocks(tb testing.TB) (string, [][]string) {
+	input := readInput(tb, "../data/day_five_ex.txt")
+	blocks := make([][]string, 0)
+	for _, line := range input[1:] {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		if strings.HasSuffix(line, "map:") {
+			blocks = append(blocks, []string{})
+		}
+		blocks[len(blocks)-1] = append(blocks[len(blocks)-1], line)
+	}
+	return input[0], blocks
+}
+
+// Joins a seeds line and map blocks back into almanac input
+func joinBlocks(seeds string, blocks [][]string) []string {
+	input := []string{seeds}
+	for _, block := range blocks {
+		input = append(input, "")
+		input = append(input, block...)
+	}
+	return input
+}
+
+// Maps can appear in any order - the answers don't change
+func TestCategoryGraphReordered(t *testing.T) {
+	seeds, blocks := exampleBlocks(t)
+	slices.Reverse(blocks)
+	input := joinBlocks(seeds, blocks)
+	for part, want := range map[int]int{1: 35, 2: 46} {
+		result, err := SolveDayFive(&input, part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Answer != want {
+			t.Errorf("reordered part %d = %d, want %d", part, result.Answer, want)
+		}
+	}
+}
+
+// Branching categories follow the shortest chain, and any two connected categories can
+// be translated between
+func TestCategoryGraphBranching(t *testing.T) {
+	input := []string{
+		"seeds: 1",
+		"seed-to-soil map:",
+		"10 0 5",
+		"soil-to-water map:",
+		"20 10 5",
+		"seed-to-water map:",
+		"30 0 5",
+		"water-to-light map:",
+		"40 30 5",
+	}
+	graph, err := ParseCategoryGraph(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := graph.Categories(), []string{"light", "seed", "soil", "water"}; !slices.Equal(got, want) {
+		t.Errorf("categories = %v, want %v", got, want)
+	}
+
+	path, err := graph.Path("seed", "light")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, len(*path))
+	for i, translator := range *path {
+		names[i] = translator.TranslatorType
+	}
+	if want := []string{"seed-to-water", "water-to-light"}; !slices.Equal(names, want) {
+		t.Errorf("seed to light path = %v, want %v", names, want)
+	}
+
+	cases := []struct {
+		id       int
+		from, to string
+		want     int
+	}{
+		{2, "seed", "light", 42},  // seed-to-water skips soil
+		{2, "soil", "water", 2},   // unmapped soil id
+		{12, "soil", "light", 22}, // soil-to-water, then water-to-light leaves 22 alone
+		{7, "water", "water", 7},  // same category is an empty chain
+	}
+	for _, c := range cases {
+		got, err := graph.Translate(c.id, c.from, c.to)
+		if err != nil || got != c.want {
+			t.Errorf("%s %d -> %s = %d, %v, want %d", c.from, c.id, c.to, got, err, c.want)
+		}
+		mapping, err := graph.Mapping(c.from, c.to)
+		if err != nil || mapping.Translate(c.id) != c.want {
+			t.Errorf("%s mapping %d -> %s = %v, want %d", c.from, c.id, c.to, err, c.want)
+		}
+	}
+}
+
+// Unreachable and unknown categories, duplicate maps, and a missing location are errors
+func TestCategoryGraphErrors(t *testing.T) {
+	seeds, blocks := exampleBlocks(t)
+	input := joinBlocks(seeds, blocks)
+	graph, err := ParseCategoryGraph(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := graph.Path("location", "seed"); err == nil {
+		t.Error("location to seed should be unreachable")
+	}
+	if _, err := graph.Translate(1, "seed", "gravel"); err == nil {
+		t.Error("gravel should be an unknown category")
+	}
+
+	duplicated := joinBlocks(seeds, append(blocks, blocks[0]))
+	if _, err := ParseCategoryGraph(&duplicated); err == nil ||
+		!strings.Contains(err.Error(), "duplicate seed-to-soil map") {
+		t.Errorf("duplicate map error = %v", err)
+	}
+
+	// drop humidity-to-location - seeds can't reach a location any more
+	truncated := joinBlocks(seeds, blocks[:len(blocks)-1])
+	if _, err := ParseDayFive(&truncated); err == nil {
+		t.Error("almanac without a location map should fail to parse")
+	}
+}
@@ -1,11 +1,12 @@
 package day_five
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"math"
 	"regexp"
-	"strconv"
-	"time"
+	"strings"
 
 	"github.com/dswelbor/adventofcode/aoc2023/utility"
 )
@@ -14,12 +15,30 @@ type Translator struct {
 	TranslatorType string
 	fromRanges     *[][]int
 	toRanges       *[][]int
+	line           int   // 1-based input line of the "<<source>>-to-<<dest>> map:" header
+	rangeLines     []int // 1-based input line of each range, parallel to fromRanges
+}
+
+// Source category name from the TranslatorType, ex. "seed" for "seed-to-soil"
+func (t *Translator) Source() string {
+	source, _, _ := strings.Cut(t.TranslatorType, "-to-")
+	return source
+}
+
+// Destination category name from the TranslatorType, ex. "soil" for "seed-to-soil"
+func (t *Translator) Destination() string {
+	_, dest, _ := strings.Cut(t.TranslatorType, "-to-")
+	return dest
 }
 
 func (t *Translator) Translate(fromId int) int {
 	// TODO: Implement me
 	// iterate through from or src ranges
 	toId := fromId // if id not in range, default fromId maps to toId
+	// edge case - a map with no ranges maps every id to itself
+	if t.fromRanges == nil || t.toRanges == nil {
+		return toId
+	}
 	for i, fromRange := range *t.fromRanges {
 		incMin := fromRange[0]
 		excMax := fromRange[1]
@@ -37,6 +56,50 @@ func (t *Translator) Translate(fromId int) int {
 	return toId
 }
 
+// Translates a set of [start, end) id intervals. Intervals are split at the Translator's
+// range boundaries: pieces inside a range are shifted to the destination range, and pieces
+// outside every range pass through unchanged (just like Translate() does for single ids)
+func (t *Translator) TranslateRanges(idRanges *[][]int) *[][]int {
+	translated := make([][]int, 0, len(*idRanges))
+	// edge case - no ranges means every id maps to itself
+	if t.fromRanges == nil || t.toRanges == nil {
+		translated = append(translated, *idRanges...)
+		return &translated
+	}
+
+	// pieces that haven't been mapped yet still need to be checked against later ranges
+	pending := make([][]int, len(*idRanges))
+	copy(pending, *idRanges)
+	for i, fromRange := range *t.fromRanges {
+		srcMin := fromRange[0]
+		srcMax := fromRange[1]
+		shift := (*t.toRanges)[i][0] - srcMin
+		unmapped := make([][]int, 0, len(pending))
+		for _, idRange := range pending {
+			start := idRange[0]
+			end := idRange[1]
+			// piece before the source range - unmapped
+			if beforeEnd := min(end, srcMin); start < beforeEnd {
+				unmapped = append(unmapped, []int{start, beforeEnd})
+			}
+			// piece overlapping the source range - shift it to the destination
+			overlapStart := max(start, srcMin)
+			overlapEnd := min(end, srcMax)
+			if overlapStart < overlapEnd {
+				translated = append(translated, []int{overlapStart + shift, overlapEnd + shift})
+			}
+			// piece after the source range - unmapped
+			if afterStart := max(start, srcMax); afterStart < end {
+				unmapped = append(unmapped, []int{afterStart, end})
+			}
+		}
+		pending = unmapped
+	}
+	// anything left over didn't fall in any range - it passes through
+	translated = append(translated, pending...)
+	return &translated
+}
+
 func (t *Translator) AddRange(fromStart int, toStart int, rangeLength int) {
 	// note: these ranges are [idStart, idEnd). That is the first element is an
 	// inclusive min and the second element is an exclusive max
@@ -69,51 +132,156 @@ func (t *Translator) AddRange(fromStart int, toStart int, rangeLength int) {
 	t.toRanges = &toRanges
 }
 
-// High level entry Point for Day 4 solution
-func SolveDayFive(input *[]string, part int) {
+/*
+Day 5 Solver: wraps ParseDayFive and adds the optional Linter for almanac input, the
+Referencer for part 2, and the Exporter
+*/
+type almanacSolver struct {
+	utility.Solver
+}
+
+// Linter.Lint() implementation: delegates to LintDayFive
+func (s almanacSolver) Lint(input *[]string) (*utility.LintReport, error) {
+	return LintDayFive(input)
+}
+
+// Referencer.ReferenceParts() implementation: only part 2 has a brute force reference
+func (s almanacSolver) ReferenceParts() []int {
+	return []int{2}
+}
+
+// Referencer.Reference() implementation: brute forces part 2
+func (s almanacSolver) Reference(ctx context.Context, input *[]string, part int,
+	opts utility.ReferenceOptions) (*utility.Result, error) {
+	if part != 2 {
+		return nil, fmt.Errorf("day 5 part %d doesn't have a reference implementation", part)
+	}
+	return referencePartTwo(ctx, input, opts)
+}
+
+// Exporter.Export() implementation: delegates to ExportDayFive
+func (s almanacSolver) Export(writer io.Writer, input *[]string,
+	opts utility.ExportOptions) error {
+	return ExportDayFive(writer, input, opts)
+}
+
+// Day 5 Solver - registered with the central Solver registry
+var dayFiveSolver = almanacSolver{utility.NewSolver(5, []int{1, 2}, ParseDayFive)}
+
+func init() {
+	utility.RegisterSolver(dayFiveSolver)
+}
+
+// Parsed Day 5 input: initial seed ids, the graph of category maps, the ordered
+// seed-to-location Translators, and those Translators composed into a single mapping
+type almanac struct {
+	seedIds     *[]int
+	categories  *CategoryGraph
+	translators *[]Translator
+	locationMap *PiecewiseMapping
+}
+
+// Parses the Day 5 input into a Puzzle shared by both parts: the seed ids from the
+// first line of input, and the ordered list of Translators
+func ParseDayFive(input *[]string) (utility.Puzzle, error) {
+	// edge case - nothing to parse
+	if len(*input) == 0 {
+		return nil, utility.NewParseError(0, 0, "empty almanac", nil)
+	}
+	// get seed ids - we assume this is the first line of input
+	numReg := regexp.MustCompile("\\d+")
+	seedIdStrings, seedIdColumns := utility.FindAllWithColumns(numReg, (*input)[0])
+	if len(seedIdStrings) == 0 {
+		return nil, utility.NewParseError(1, 0, "missing seed ids", nil)
+	}
+	seedIds, err := utility.ListAtoi(&seedIdStrings, &seedIdColumns)
+	if err != nil {
+		return nil, utility.AtLine(err, 1)
+	}
+
+	// get the category graph, then the chain of translators from seeds to locations
+	categories, err := ParseCategoryGraph(input)
+	if err != nil {
+		return nil, err
+	}
+	translators, err := categories.Path("seed", "location")
+	if err != nil {
+		return nil, utility.NewParseError(0, 0, "invalid almanac", err)
+	}
 
+	// compose the chain once so any seed resolves with a single lookup
+	locationMap := ComposeChain(translators)
+	if utility.LogEnabled(utility.LevelTrace) {
+		utility.Tracef("composed %s", locationMap)
+	}
+
+	return &almanac{
+		seedIds:     seedIds,
+		categories:  categories,
+		translators: translators,
+		locationMap: locationMap,
+	}, nil
+}
+
+// Parses the almanac maps into a CategoryGraph so ids can be translated between any two
+// categories, ex. soil to humidity
+func ParseCategoryGraph(input *[]string) (*CategoryGraph, error) {
+	translators, err := initTranslators(input)
+	if err != nil {
+		return nil, err
+	}
+	return NewCategoryGraph(translators)
+}
+
+// Puzzle.Solve() implementation: solves a part against the shared parsed input
+func (p *almanac) Solve(part int) (*utility.Result, error) {
 	if part == 1 {
-		solvePartOne(input)
+		return solvePartOne(p), nil
 	} else if part == 2 {
-		solvePartTwo(input)
-	} else {
-		fmt.Println("Part: " + strconv.Itoa(part) + "Not supported")
+		return solvePartTwo(p)
 	}
+	return nil, fmt.Errorf("part %d not supported", part)
+}
 
+// High level entry point: parses the input and solves a single part
+func SolveDayFive(input *[]string, part int) (*utility.Result, error) {
+	return dayFiveSolver.Solve(input, part)
 }
 
 // Entry point for day 5 part 1 solution
-func solvePartOne(input *[]string) {
-	fmt.Println("--- Solving Day Five - Part One! ---")
-	minLocId := naiveLowestLocation(input)
-	fmt.Println("Lowest Location Id for initial seeds: ", minLocId)
+func solvePartOne(parsed *almanac) *utility.Result {
+	minLocId := naiveLowestLocation(parsed.seedIds, parsed.locationMap)
+	result := utility.NewResult(5, 1, "Lowest Location Id for initial seeds", minLocId)
+	result.AddDiagnostic(fmt.Sprintf("%s segments: %d", parsed.locationMap.Name,
+		len(parsed.locationMap.segments)))
+	return result
 }
 
 // Entry point for day 5 part 2 solution
-func solvePartTwo(input *[]string) {
-	fmt.Println("--- Solving Day Five - Part Two! ---")
-	// Let's time how long solution takes to complete
-	start := time.Now()
-	minLocId := rangedLowestLocation(input)
-	elapsed := time.Since(start)
-	fmt.Println("\nComplete! Lowest Location Id for seeds ranges: ", minLocId)
-	fmt.Printf("Time elapsed: %s\n", elapsed)
+func solvePartTwo(parsed *almanac) (*utility.Result, error) {
+	if err := checkSeedIdPairs(parsed.seedIds); err != nil {
+		return nil, err
+	}
+	minLocId := rangedLowestLocation(parsed.seedIds, parsed.translators)
+	return utility.NewResult(5, 2, "Lowest Location Id for seeds ranges", minLocId), nil
 }
 
-func naiveLowestLocation(input *[]string) int {
-	// get seed ids - we assume this is the first line of input
-	numReg := regexp.MustCompile("\\d+")
-	seedIdStrings := numReg.FindAllString((*input)[0], -1)
-	seedIds := utility.ListAtoi(&seedIdStrings)
-
-	// get ordered list of translators
-	translators := initTranslators(input)
+// Seed ids are read in (start, length) pairs for part 2 - returns a ParseError for an odd
+// count of seed numbers
+func checkSeedIdPairs(seedIds *[]int) error {
+	if len(*seedIds)%2 != 0 {
+		return utility.NewParseError(1, 0,
+			fmt.Sprintf("expected seed id ranges in pairs, found %d numbers", len(*seedIds)),
+			nil)
+	}
+	return nil
+}
 
+func naiveLowestLocation(seedIds *[]int, locationMap *PiecewiseMapping) int {
 	// map seed ids to location ids and find lowest location id
-	seedLocMap := translateSeeds(seedIds, translators)
 	minLocId := math.MaxInt
-	for _, locId := range *seedLocMap {
-		if locId < minLocId {
+	for _, seedId := range *seedIds {
+		if locId := locationMap.Translate(seedId); locId < minLocId {
 			minLocId = locId
 		}
 	}
@@ -121,16 +289,10 @@ func naiveLowestLocation(input *[]string) int {
 	return minLocId
 }
 
-func rangedLowestLocation(input *[]string) int {
-	// get seed ids - we assume this is the first line of input
-	numReg := regexp.MustCompile("\\d+")
-	seedIdStrings := numReg.FindAllString((*input)[0], -1)
-	seedIds := utility.ListAtoi(&seedIdStrings)
+func rangedLowestLocation(seedIds *[]int, translators *[]Translator) int {
+	// pair up seed ids into ranges
 	seedIdRanges := parseSeedIdRanges(seedIds)
 
-	// get ordered list of translators
-	translators := initTranslators(input)
-
 	// map seed ids to location ids and find lowest location id
 	minLocId := minLocFromSeedRanges(seedIdRanges, translators)
 	// lowest location id found
@@ -155,30 +317,14 @@ func parseSeedIdRanges(seedNumbersPtr *[]int) *[][]int {
 		seedRange := []int{incMin, excMax}
 		seedRanges[i/2] = seedRange
 	}
-	fmt.Println("[INFO] Finished parsing ", seedNumCount/2, " seedId ranges")
+	utility.Debugf("Finished parsing %d seedId ranges", seedNumCount/2)
 	// We've got our list of seed ranges
 	return &seedRanges
 }
 
-// Turns an id range with an inclusive min and exclusive max [minId, maxId) into a list
-// of seed ids
-func parseSeedIdsFromRanges(seedRange *[]int) *[]int {
-	// parse inclusive min and exclusive max from range
-	incMin := (*seedRange)[0]
-	excMax := (*seedRange)[1]
-	length := excMax - incMin
-	// blow out id list
-	seedIds := make([]int, length)
-	for offset := 0; offset < length; offset++ {
-		seedId := incMin + offset
-		seedIds[offset] = seedId
-	}
-	// we've got our seed ids
-	return &seedIds
-}
-
-// creates an ordered list of translators to go from seed id to location id
-func initTranslators(input *[]string) *[]Translator {
+// creates an ordered list of translators to go from seed id to location id. Returns a
+// ParseError if a range line isn't '<<dest>> <<source>> <<length>>'
+func initTranslators(input *[]string) (*[]Translator, error) {
 	// init variables to persist parsed data across multiple lines of input
 	var translator Translator
 	var translatorType string
@@ -195,45 +341,45 @@ func initTranslators(input *[]string) *[]Translator {
 
 		// Parse numbers and from-to-dest mapping
 		translatorMatch := mapReg.FindString(inputStr)
-		numberMatches := numReg.FindAllString(inputStr, -1)
+		numberMatches, numberColumns := utility.FindAllWithColumns(numReg, inputStr)
 		if len(translatorMatch) > 0 {
-			// Were's on a fromType-to-destType mapping line
+			// Were's on a fromType-to-destType mapping line - the previous translator
+			// (if any) is done
+			if len(translator.TranslatorType) > 0 {
+				translators = append(translators, translator)
+			}
 			translatorType = translatorMatch // set the type - persist some iterations
-			translator = Translator{TranslatorType: translatorType}
+			translator = Translator{TranslatorType: translatorType, line: i + 1}
 		} else if len(numberMatches) > 0 {
 			// parse input string with '<<dest>> <<source>> <<length>>' data
-			destStartId, destErr := strconv.Atoi(numberMatches[0])
-			srcStartId, srcErr := strconv.Atoi(numberMatches[1])
-			rangeLength, rngErr := strconv.Atoi(numberMatches[2])
-			if destErr != nil || srcErr != nil || rngErr != nil {
-				fmt.Println("[ERROR] Couldn't parse numbers from: ", numberMatches)
+			if len(numberMatches) != 3 {
+				return nil, utility.NewParseError(i+1, 0,
+					fmt.Sprintf("expected 3 numbers (dest, source, length), found %d",
+						len(numberMatches)), nil)
+			}
+			if len(translator.TranslatorType) == 0 {
+				return nil, utility.NewParseError(i+1, 0,
+					"range found before a \"<<source>>-to-<<dest>> map:\" header", nil)
 			}
+			rangeNumbers, err := utility.ListAtoi(&numberMatches, &numberColumns)
+			if err != nil {
+				return nil, utility.AtLine(err, i+1)
+			}
+			destStartId := (*rangeNumbers)[0]
+			srcStartId := (*rangeNumbers)[1]
+			rangeLength := (*rangeNumbers)[2]
 			// Add range to translator
 			translator.AddRange(srcStartId, destStartId, rangeLength)
-		} else if len(translator.TranslatorType) > 0 || i == len(*input)-1 {
-			// translator exists and no regex matches
-			// no more dest src range to addto translator - add translator to list
-			translators = append(translators, translator)
-		}
-		// edge case - input doesn't have an empty line at the end
-		if i == len(*input)-1 {
-			translators = append(translators, translator)
+			translator.rangeLines = append(translator.rangeLines, i+1)
 		}
 	}
-	fmt.Println("[INFO] Finished building ", len(translators), " translators from input")
-	// Done building ordered list of translators (translate behaviors)
-	return &translators
-}
-
-// Helper function that iteratively maps seedId to locationId
-func translateSeeds(seedIds *[]int, translators *[]Translator) *map[int]int {
-	// iterate through the list of seed ids and map seedId: locationId
-	seedLocMap := make(map[int]int)
-	for _, seedId := range *seedIds {
-		locId := translateSeed(seedId, translators)
-		seedLocMap[seedId] = locId
+	// last translator doesn't have a header after it
+	if len(translator.TranslatorType) > 0 {
+		translators = append(translators, translator)
 	}
-	return &seedLocMap
+	utility.Debugf("Finished building %d translators from input", len(translators))
+	// Done building ordered list of translators (translate behaviors)
+	return &translators, nil
 }
 
 // Helper function that takes a seed id and a list of ordered translators, and
@@ -249,35 +395,22 @@ func translateSeed(seedId int, translators *[]Translator) int {
 	return locId
 }
 
-// Helper function that iteratively maps seedId to locationId
+// Helper function that propagates seed id ranges through the translators as intervals,
+// then returns the lowest location id from the resulting location id ranges
 func minLocFromSeedRanges(seedIdRangesPtr *[][]int, translators *[]Translator) int {
-	// iterate through the list of seed ids and map seedId: locationId
-	// seedLocMap := make(map[int]int)
-	minLocId := math.MaxInt
-	for chunkCount, seedIdRange := range *seedIdRangesPtr {
-		seedIds := parseSeedIdsFromRanges(&seedIdRange)
-		// partSeedLocMap := translateSeeds(seedIds, translators)
-		locId := minLocFromSeeds(seedIds, translators)
-		// Check and store lowest location id value
-		if locId < minLocId {
-			minLocId = locId
-		}
-		fmt.Println("[INFO] Finished chunk: ", chunkCount, " / ", len(*seedIdRangesPtr))
+	// translate the whole interval set one translator at a time
+	idRanges := seedIdRangesPtr
+	for _, translator := range *translators {
+		idRanges = translator.TranslateRanges(idRanges)
+		utility.Tracef("%s: %d id ranges", translator.TranslatorType, len(*idRanges))
 	}
-	return minLocId
-}
 
-func minLocFromSeeds(seedIds *[]int, translators *[]Translator) int {
-	// iterate through the list of seed ids and map seedId: locationId
+	// lowest location id is the smallest start of a non-empty location range
 	minLocId := math.MaxInt
-	for _, seedId := range *seedIds {
-		locId := translateSeed(seedId, translators)
-		// we got the locId - let's store the min value
-		if locId < minLocId {
-			minLocId = locId
+	for _, locRange := range *idRanges {
+		if locRange[0] < locRange[1] && locRange[0] < minLocId {
+			minLocId = locRange[0]
 		}
 	}
-
 	return minLocId
-
 }
@@ -0,0 +1,135 @@
+package day_five
+
+import (
+	"context"
+	"maps"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Reads a day five input file from data/ into lines
+func readInput(tb testing.TB, filepath string) []string {
+	content, err := os.ReadFile(filepath)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+}
+
+// Random chains checked per test - small ranges keep brute force comparisons cheap
+const randomChains = 500
+
+// Builds a random seed-to-... chain of 1-4 Translators, each with up to 4 small ranges
+// that may overlap, be zero-length, or be missing entirely. Every range lies in [0, 120).
+func randomChain(random *rand.Rand) *[]Translator {
+	categories := []string{"seed", "soil", "fertilizer", "water", "light"}
+	translators := make([]Translator, 1+random.Intn(4))
+	for i := range translators {
+		translators[i] = Translator{TranslatorType: categories[i] + "-to-" + categories[i+1]}
+		for r := random.Intn(5); r > 0; r-- {
+			translators[i].AddRange(random.Intn(100), random.Intn(100), random.Intn(20))
+		}
+	}
+	return &translators
+}
+
+// Ids checked against random chains: every range plus some unmapped ids on either side
+const randomIdMin, randomIdMax = -20, 150
+
+// The example almanac with the seed-to-soil ranges removed, leaving an empty map
+func emptyMapInput(tb testing.TB) []string {
+	input := readInput(tb, "../data/day_five_ex.txt")
+	emptied := make([]string, 0, len(input))
+	skipping := false
+	for _, line := range input {
+		if strings.HasPrefix(line, "seed-to-soil map:") {
+			skipping = true
+			emptied = append(emptied, line)
+			continue
+		}
+		if skipping && len(strings.TrimSpace(line)) > 0 {
+			continue
+		}
+		skipping = false
+		emptied = append(emptied, line)
+	}
+	return emptied
+}
+
+// A map with no ranges maps every id to itself - for single ids, the category graph,
+// and the brute force reference
+func TestEmptyMapTranslate(t *testing.T) {
+	empty := Translator{TranslatorType: "seed-to-soil"}
+	if got := empty.Translate(79); got != 79 {
+		t.Errorf("empty Translate(79) = %d, want 79", got)
+	}
+
+	input := emptyMapInput(t)
+	graph, err := ParseCategoryGraph(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soilId, err := graph.Translate(79, "seed", "soil"); err != nil || soilId != 79 {
+		t.Errorf("graph seed 79 -> soil = %d, %v, want 79", soilId, err)
+	}
+
+	solved, err := SolveDayFive(&input, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference, err := referencePartTwo(context.Background(), &input,
+		utility.ReferenceOptions{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reference.Answer != solved.Answer {
+		t.Errorf("reference = %d, solver = %d", reference.Answer, solved.Answer)
+	}
+}
+
+// Translating intervals should produce exactly the ids that translating each seed id one
+// at a time does
+func TestTranslateRanges(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for n := 0; n < randomChains; n++ {
+		translators := randomChain(random)
+		idRanges := make([][]int, 0)
+		for r := 1 + random.Intn(3); r > 0; r-- {
+			start := randomIdMin + random.Intn(randomIdMax-randomIdMin)
+			idRanges = append(idRanges, []int{start, start + random.Intn(40)})
+		}
+
+		want := make(map[int]bool)
+		wantMin := math.MaxInt
+		for _, idRange := range idRanges {
+			for id := idRange[0]; id < idRange[1]; id++ {
+				locId := translateSeed(id, translators)
+				want[locId] = true
+				wantMin = min(wantMin, locId)
+			}
+		}
+		got := make(map[int]bool)
+		translated := &idRanges
+		for i := range *translators {
+			translated = (*translators)[i].TranslateRanges(translated)
+		}
+		for _, idRange := range *translated {
+			for id := idRange[0]; id < idRange[1]; id++ {
+				got[id] = true
+			}
+		}
+		if !maps.Equal(got, want) {
+			t.Fatalf("chain %d: TranslateRanges(%v) gives %d ids, translateSeed gives %d",
+				n, idRanges, len(got), len(want))
+		}
+		if gotMin := minLocFromSeedRanges(&idRanges, translators); gotMin != wantMin {
+			t.Fatalf("chain %d: minLocFromSeedRanges(%v) = %d, want %d", n, idRanges,
+				gotMin, wantMin)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package day_five
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+A single step of a traced id through the chain: which Translator was used, the id going
+in and coming out, and the matched range (-1 when the id passed through unmapped)
+*/
+type traceStep struct {
+	translator *Translator
+	fromId     int
+	toId       int
+	rangeIndex int
+}
+
+// Day 5 exporter: writes the seed-to-location Translator chain as Graphviz
+// DOT ("dot") or CSV ("csv"). Traced seed ids are drawn as extra paths in DOT, and in CSV
+// replace the range table with one row per translation step.
+func ExportDayFive(writer io.Writer, input *[]string, opts utility.ExportOptions) error {
+	puzzle, err := ParseDayFive(input)
+	if err != nil {
+		return err
+	}
+	translators := puzzle.(*almanac).translators
+
+	switch strings.ToLower(opts.Format) {
+	case "dot":
+		return writeDot(writer, translators, opts.Trace)
+	case "csv":
+		if len(opts.Trace) > 0 {
+			return writeTraceCsv(writer, translators, opts.Trace)
+		}
+		return writeRangesCsv(writer, translators)
+	}
+	return fmt.Errorf("export format %q not supported: use dot or csv", opts.Format)
+}
+
+// Like Translate(), but also returns the index of the matched range (-1 when unmapped)
+func (t *Translator) lookup(fromId int) (int, int) {
+	if t.fromRanges == nil || t.toRanges == nil {
+		return fromId, -1
+	}
+	for i, fromRange := range *t.fromRanges {
+		if fromId >= fromRange[0] && fromId < fromRange[1] {
+			return (*t.toRanges)[i][0] + fromId - fromRange[0], i
+		}
+	}
+	return fromId, -1
+}
+
+// Follows an id through each Translator in the chain, recording every step
+func traceId(id int, translators *[]Translator) []traceStep {
+	steps := make([]traceStep, len(*translators))
+	for i := range *translators {
+		translator := &(*translators)[i]
+		toId, rangeIndex := translator.lookup(id)
+		steps[i] = traceStep{translator: translator, fromId: id, toId: toId, rangeIndex: rangeIndex}
+		id = toId
+	}
+	return steps
+}
+
+// Writes the chain as a left to right DOT digraph: one node per category, one edge per
+// Translator labeled with its ranges, and a dashed path of id nodes per traced seed
+func writeDot(writer io.Writer, translators *[]Translator, trace []int) error {
+	var sb strings.Builder
+	sb.WriteString("digraph almanac {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for i := range *translators {
+		translator := &(*translators)[i]
+		// one label line per range, ex. "[98, 100) -> [50, 52) (-48)"
+		labelLines := []string{translator.TranslatorType}
+		if translator.fromRanges != nil {
+			for j, fromRange := range *translator.fromRanges {
+				toRange := (*translator.toRanges)[j]
+				labelLines = append(labelLines, fmt.Sprintf("[%d, %d) -> [%d, %d) (%+d)",
+					fromRange[0], fromRange[1], toRange[0], toRange[1], toRange[0]-fromRange[0]))
+			}
+		}
+		sb.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", translator.Source(),
+			translator.Destination(), strings.Join(labelLines, "\n")))
+	}
+
+	// traced seeds - one ellipse per category the id passes through
+	for t, seedId := range trace {
+		steps := traceId(seedId, translators)
+		if len(steps) == 0 {
+			continue
+		}
+		first := fmt.Sprintf("trace%d_%s", t, steps[0].translator.Source())
+		sb.WriteString(fmt.Sprintf("  %q [shape=ellipse, label=%q];\n", first,
+			steps[0].translator.Source()+" "+strconv.Itoa(seedId)))
+		previous := first
+		for _, step := range steps {
+			node := fmt.Sprintf("trace%d_%s", t, step.translator.Destination())
+			sb.WriteString(fmt.Sprintf("  %q [shape=ellipse, label=%q];\n", node,
+				step.translator.Destination()+" "+strconv.Itoa(step.toId)))
+			sb.WriteString(fmt.Sprintf("  %q -> %q [style=dashed, label=%q];\n", previous, node,
+				fmt.Sprintf("%+d", step.toId-step.fromId)))
+			previous = node
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+// Writes one CSV row per Translator range
+func writeRangesCsv(writer io.Writer, translators *[]Translator) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"map", "source", "destination", "line", "source_start",
+		"source_end", "destination_start", "destination_end", "shift"})
+	for i := range *translators {
+		translator := &(*translators)[i]
+		if translator.fromRanges == nil {
+			continue
+		}
+		for j, fromRange := range *translator.fromRanges {
+			toRange := (*translator.toRanges)[j]
+			csvWriter.Write([]string{translator.TranslatorType, translator.Source(),
+				translator.Destination(), strconv.Itoa(translator.rangeLine(j)),
+				strconv.Itoa(fromRange[0]), strconv.Itoa(fromRange[1]),
+				strconv.Itoa(toRange[0]), strconv.Itoa(toRange[1]),
+				strconv.Itoa(toRange[0] - fromRange[0])})
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// Writes one CSV row per translation step of each traced seed id. line is the input line
+// of the matched range, or empty when the id passed through unmapped.
+func writeTraceCsv(writer io.Writer, translators *[]Translator, trace []int) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"seed", "step", "map", "source", "from_id", "destination",
+		"to_id", "shift", "line"})
+	for _, seedId := range trace {
+		for i, step := range traceId(seedId, translators) {
+			line := ""
+			if step.rangeIndex >= 0 {
+				line = strconv.Itoa(step.translator.rangeLine(step.rangeIndex))
+			}
+			csvWriter.Write([]string{strconv.Itoa(seedId), strconv.Itoa(i + 1),
+				step.translator.TranslatorType, step.translator.Source(),
+				strconv.Itoa(step.fromId), step.translator.Destination(),
+				strconv.Itoa(step.toId), strconv.Itoa(step.toId - step.fromId), line})
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,60 @@
+package day_five
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Exports the example almanac, failing the test on errors
+func exportExample(tb testing.TB, opts utility.ExportOptions) string {
+	input := readInput(tb, "../data/day_five_ex.txt")
+	var sb strings.Builder
+	if err := ExportDayFive(&sb, &input, opts); err != nil {
+		tb.Fatal(err)
+	}
+	return sb.String()
+}
+
+// DOT has an edge per map and a traced path per seed, and CSV has a row per range or per
+// traced step
+func TestExportDayFive(t *testing.T) {
+	dot := exportExample(t, utility.ExportOptions{Format: "dot", Trace: []int{79}})
+	for _, want := range []string{
+		"digraph almanac {",
+		`"seed" -> "soil"`,
+		`"humidity" -> "location"`,
+		`"trace0_location" [shape=ellipse, label="location 82"]`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot export missing %q:\n%s", want, dot)
+		}
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(
+		exportExample(t, utility.ExportOptions{Format: "csv"}))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header plus the example's 18 ranges
+	if len(rows) != 19 || strings.Join(rows[1], ",") != "seed-to-soil,seed,soil,4,98,100,50,52,-48" {
+		t.Errorf("ranges csv has %d rows, first range %v", len(rows), rows[1])
+	}
+
+	rows, err = csv.NewReader(strings.NewReader(
+		exportExample(t, utility.ExportOptions{Format: "csv", Trace: []int{79, 14}}))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header plus 7 steps per traced seed - seed 79 ends at location 82, 14 at 43
+	if len(rows) != 15 || rows[7][6] != "82" || rows[14][6] != "43" {
+		t.Errorf("trace csv = %v", rows)
+	}
+
+	input := readInput(t, "../data/day_five_ex.txt")
+	if err := ExportDayFive(&strings.Builder{}, &input, utility.ExportOptions{Format: "png"}); err == nil {
+		t.Error("png export should be an error")
+	}
+}
@@ -0,0 +1,121 @@
+package day_five
+
+import (
+	"slices"
+	"sort"
+)
+
+// Translates a destination id back to every source id that produces it. An id can have
+// several sources: one per range it lands in, plus itself when no range claims it.
+func (t *Translator) InverseTranslate(toId int) []int {
+	// edge case - no ranges means every id maps to itself
+	if t.fromRanges == nil || t.toRanges == nil {
+		return []int{toId}
+	}
+	fromIds := make([]int, 0)
+	// toId maps to itself if no source range covers it
+	if t.Translate(toId) == toId {
+		fromIds = append(fromIds, toId)
+	}
+	for i, toRange := range *t.toRanges {
+		if toId < toRange[0] || toId >= toRange[1] {
+			continue
+		}
+		// only count it if this range is the one Translate() would pick for the source id
+		fromId := (*t.fromRanges)[i][0] + toId - toRange[0]
+		if t.Translate(fromId) == toId && !slices.Contains(fromIds, fromId) {
+			fromIds = append(fromIds, fromId)
+		}
+	}
+	slices.Sort(fromIds)
+	return fromIds
+}
+
+// Translates a destination id back through an ordered chain of Translators, ex. a
+// location id back to every seed id that produces it
+func InverseTranslateChain(toId int, translators *[]Translator) []int {
+	ids := []int{toId}
+	// walk the chain backwards - each step can fan out to several source ids
+	for i := len(*translators) - 1; i >= 0; i-- {
+		fromIds := make([]int, 0, len(ids))
+		for _, id := range ids {
+			fromIds = append(fromIds, (*translators)[i].InverseTranslate(id)...)
+		}
+		slices.Sort(fromIds)
+		ids = slices.Compact(fromIds)
+	}
+	return ids
+}
+
+/*
+Segments of a PiecewiseMapping sorted by where they land (Start + Shift) so a
+destination id can be looked up with a binary search
+*/
+type inverseIndex struct {
+	images    []Segment
+	maxLength int
+}
+
+// Constructor indexes segments by destination
+func newInverseIndex(segments []Segment) *inverseIndex {
+	images := make([]Segment, len(segments))
+	copy(images, segments)
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Start+images[i].Shift < images[j].Start+images[j].Shift
+	})
+	maxLength := 0
+	for _, segment := range images {
+		maxLength = max(maxLength, segment.End-segment.Start)
+	}
+	return &inverseIndex{images: images, maxLength: maxLength}
+}
+
+// Translates a destination id back to every source id that produces it
+func (m *PiecewiseMapping) InverseTranslate(toId int) []int {
+	fromIds := make([]int, 0)
+	// toId maps to itself if it's outside every segment
+	if m.Translate(toId) == toId {
+		fromIds = append(fromIds, toId)
+	}
+	// segments landing on toId start at or before it, and no more than the longest
+	// segment length before it - walk back from the last segment starting at or before it
+	images := m.inverse.images
+	i := sort.Search(len(images), func(i int) bool { return images[i].Start+images[i].Shift > toId })
+	for i--; i >= 0; i-- {
+		segment := images[i]
+		imageStart := segment.Start + segment.Shift
+		if imageStart <= toId-m.inverse.maxLength {
+			break
+		}
+		if toId < segment.End+segment.Shift {
+			fromIds = append(fromIds, toId-segment.Shift)
+		}
+	}
+	slices.Sort(fromIds)
+	return fromIds
+}
+
+// Searches location ids upwards from 0 through maxLocId and returns the first one that
+// maps back into a seed range. An independent check on minLocFromSeedRanges(). Returns
+// false when no location up to maxLocId comes from a seed.
+func searchLowestLocation(seedIdRanges *[][]int, locationMap *PiecewiseMapping,
+	maxLocId int) (int, bool) {
+	for locId := 0; locId <= maxLocId; locId++ {
+		for _, seedId := range locationMap.InverseTranslate(locId) {
+			if inSeedRanges(seedId, seedIdRanges) {
+				return locId, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Reports whether a seed id falls in any [start, end) seed range
+func inSeedRanges(seedId int, seedIdRanges *[][]int) bool {
+	for _, seedRange := range *seedIdRanges {
+		if seedId >= seedRange[0] && seedId < seedRange[1] {
+			return true
+		}
+	}
+	return false
+}
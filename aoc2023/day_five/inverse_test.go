@@ -0,0 +1,62 @@
+package day_five
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Searching locations upwards and mapping each back to seeds should find the same lowest
+// location as the interval solution. The real input searches ~7*10^7 locations - -short
+// skips it.
+func TestSearchLowestLocation(t *testing.T) {
+	filepaths := []string{"../data/day_five_ex.txt", "../data/day_five_input.txt"}
+	if testing.Short() {
+		filepaths = filepaths[:1]
+	}
+	for _, filepath := range filepaths {
+		input := readInput(t, filepath)
+		puzzle, err := ParseDayFive(&input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed := puzzle.(*almanac)
+		minLocId := rangedLowestLocation(parsed.seedIds, parsed.translators)
+		searchLocId, found := searchLowestLocation(parseSeedIdRanges(parsed.seedIds),
+			parsed.locationMap, minLocId)
+		if !found || searchLocId != minLocId {
+			t.Errorf("%s: inverse search found %d (found: %t), intervals found %d", filepath,
+				searchLocId, found, minLocId)
+		}
+	}
+}
+
+// Inverse translation through a chain, or through its composed mapping, should find
+// exactly the ids that translate forward to the destination id. Random ranges lie in
+// [0, 120), so ids outside the checked window map to themselves and can't be sources.
+func TestInverseTranslate(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	for n := 0; n < randomChains; n++ {
+		translators := randomChain(random)
+		sources := make(map[int][]int)
+		for id := randomIdMin; id < randomIdMax; id++ {
+			locId := translateSeed(id, translators)
+			sources[locId] = append(sources[locId], id)
+		}
+
+		composed := ComposeChain(translators)
+		for toId := randomIdMin + 10; toId < randomIdMax-10; toId++ {
+			want := sources[toId]
+			if want == nil {
+				want = []int{}
+			}
+			if got := InverseTranslateChain(toId, translators); !slices.Equal(got, want) {
+				t.Fatalf("chain %d: InverseTranslateChain(%d) = %v, want %v", n, toId, got, want)
+			}
+			if got := composed.InverseTranslate(toId); !slices.Equal(got, want) {
+				t.Fatalf("chain %d: composed %s\nInverseTranslate(%d) = %v, want %v", n,
+					composed, toId, got, want)
+			}
+		}
+	}
+}
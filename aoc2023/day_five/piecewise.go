@@ -0,0 +1,202 @@
+package day_five
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+A single piece of a PiecewiseMapping: ids in [Start, End) map to id + Shift
+*/
+type Segment struct {
+	Start int
+	End   int
+	Shift int
+}
+
+/*
+Piecewise-linear id mapping equivalent to one Translator or a whole chain of them.
+Segments are sorted by Start and don't overlap, so an id is resolved with one binary
+search. Ids outside every segment map to themselves, same as Translator.Translate().
+*/
+type PiecewiseMapping struct {
+	Name     string
+	segments []Segment
+	inverse  *inverseIndex
+}
+
+// Constructor builds the PiecewiseMapping for a single Translator. When source ranges
+// overlap, the range added first wins (that's the range Translate() would find first).
+func NewPiecewiseMapping(t *Translator) *PiecewiseMapping {
+	segments := make([]Segment, 0)
+	if t.fromRanges != nil && t.toRanges != nil {
+		for i, fromRange := range *t.fromRanges {
+			shift := (*t.toRanges)[i][0] - fromRange[0]
+			// only keep the pieces of this range that earlier ranges don't already cover
+			pieces := [][]int{{fromRange[0], fromRange[1]}}
+			for _, taken := range segments {
+				pieces = subtractInterval(pieces, taken.Start, taken.End)
+			}
+			for _, piece := range pieces {
+				segments = append(segments, Segment{Start: piece[0], End: piece[1], Shift: shift})
+			}
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].Start < segments[j].Start })
+
+	return newPiecewiseMapping(t.TranslatorType, normalizeSegments(segments))
+}
+
+// Wraps sorted, normalized segments into a PiecewiseMapping and indexes them by
+// destination for InverseTranslate()
+func newPiecewiseMapping(name string, segments []Segment) *PiecewiseMapping {
+	return &PiecewiseMapping{Name: name, segments: segments, inverse: newInverseIndex(segments)}
+}
+
+// Translates an id with a binary search over the sorted segments
+func (m *PiecewiseMapping) Translate(fromId int) int {
+	// find the first segment that ends after the id
+	i := sort.Search(len(m.segments), func(i int) bool { return m.segments[i].End > fromId })
+	if i < len(m.segments) && m.segments[i].Start <= fromId {
+		return fromId + m.segments[i].Shift
+	}
+	// not in any segment - id maps to itself
+	return fromId
+}
+
+// Returns a copy of the sorted, non-overlapping segments. Ids between segments map to
+// themselves.
+func (m *PiecewiseMapping) Segments() []Segment {
+	segments := make([]Segment, len(m.segments))
+	copy(segments, m.segments)
+	return segments
+}
+
+// Composes two mappings into one: the result is equivalent to translating with m, then
+// translating the result with next
+func (m *PiecewiseMapping) Compose(next *PiecewiseMapping) *PiecewiseMapping {
+	segments := make([]Segment, 0, len(m.segments)+len(next.segments))
+	nextPieces := next.pieces()
+	for _, piece := range m.pieces() {
+		// where this piece lands after m - then split it at next's piece boundaries
+		imageStart := piece.Start + piece.Shift
+		imageEnd := piece.End + piece.Shift
+		for _, nextPiece := range nextPieces {
+			overlapStart := max(imageStart, nextPiece.Start)
+			overlapEnd := min(imageEnd, nextPiece.End)
+			if overlapStart >= overlapEnd {
+				continue
+			}
+			// map the overlap back into m's domain so segments stay keyed by source id
+			segments = append(segments, Segment{
+				Start: overlapStart - piece.Shift,
+				End:   overlapEnd - piece.Shift,
+				Shift: piece.Shift + nextPiece.Shift,
+			})
+		}
+	}
+	// pieces are visited in domain order, and each piece's overlaps in image order, so
+	// segments are already sorted
+	return newPiecewiseMapping(composeNames(m.Name, next.Name), normalizeSegments(segments))
+}
+
+// Composes two Translators into a single PiecewiseMapping: first, then second
+func ComposeTranslators(first *Translator, second *Translator) *PiecewiseMapping {
+	return NewPiecewiseMapping(first).Compose(NewPiecewiseMapping(second))
+}
+
+// Folds an ordered chain of Translators (ex. seed-to-soil ... humidity-to-location) into a
+// single PiecewiseMapping. An empty chain maps every id to itself.
+func ComposeChain(translators *[]Translator) *PiecewiseMapping {
+	composed := newPiecewiseMapping("", make([]Segment, 0))
+	for i := range *translators {
+		mapping := NewPiecewiseMapping(&(*translators)[i])
+		if i == 0 {
+			composed = mapping
+			continue
+		}
+		composed = composed.Compose(mapping)
+	}
+	return composed
+}
+
+// Prints the mapping one segment per line, ex. "  [98, 100) -> [50, 52) (-48)"
+func (m *PiecewiseMapping) String() string {
+	var sb strings.Builder
+	sb.WriteString(m.Name + ": " + strconv.Itoa(len(m.segments)) + " segments")
+	for _, segment := range m.segments {
+		sb.WriteString("\n  [" + strconv.Itoa(segment.Start) + ", " + strconv.Itoa(segment.End) +
+			") -> [" + strconv.Itoa(segment.Start+segment.Shift) + ", " +
+			strconv.Itoa(segment.End+segment.Shift) + ") (" + strconv.Itoa(segment.Shift) + ")")
+	}
+	return sb.String()
+}
+
+// Returns segments covering every id [math.MinInt, math.MaxInt), with the gaps between
+// mapped segments filled in as identity (Shift 0) pieces
+func (m *PiecewiseMapping) pieces() []Segment {
+	pieces := make([]Segment, 0, 2*len(m.segments)+1)
+	next := math.MinInt
+	for _, segment := range m.segments {
+		if next < segment.Start {
+			pieces = append(pieces, Segment{Start: next, End: segment.Start})
+		}
+		pieces = append(pieces, segment)
+		next = segment.End
+	}
+	if next < math.MaxInt {
+		pieces = append(pieces, Segment{Start: next, End: math.MaxInt})
+	}
+	return pieces
+}
+
+// Cleans up sorted segments: drops empty and identity segments (ids outside every
+// segment already map to themselves), and merges touching segments with the same shift
+func normalizeSegments(segments []Segment) []Segment {
+	normalized := make([]Segment, 0, len(segments))
+	for _, segment := range segments {
+		if segment.Start >= segment.End || segment.Shift == 0 {
+			continue
+		}
+		last := len(normalized) - 1
+		if last >= 0 && normalized[last].End == segment.Start &&
+			normalized[last].Shift == segment.Shift {
+			normalized[last].End = segment.End
+			continue
+		}
+		normalized = append(normalized, segment)
+	}
+	return normalized
+}
+
+// Removes [cutStart, cutEnd) from each [start, end) interval, splitting where needed
+func subtractInterval(intervals [][]int, cutStart int, cutEnd int) [][]int {
+	remaining := make([][]int, 0, len(intervals)+1)
+	for _, interval := range intervals {
+		if beforeEnd := min(interval[1], cutStart); interval[0] < beforeEnd {
+			remaining = append(remaining, []int{interval[0], beforeEnd})
+		}
+		if afterStart := max(interval[0], cutEnd); afterStart < interval[1] {
+			remaining = append(remaining, []int{afterStart, interval[1]})
+		}
+	}
+	return remaining
+}
+
+// Combines "a-to-b" and "b-to-c" into "a-to-c". Falls back to joining the names.
+func composeNames(first string, second string) string {
+	if len(first) == 0 {
+		return second
+	}
+	if len(second) == 0 {
+		return first
+	}
+	firstParts := strings.SplitN(first, "-to-", 2)
+	secondParts := strings.SplitN(second, "-to-", 2)
+	if len(firstParts) == 2 && len(secondParts) == 2 && firstParts[1] == secondParts[0] {
+		return firstParts[0] + "-to-" + secondParts[1]
+	}
+	return first + " then " + second
+}
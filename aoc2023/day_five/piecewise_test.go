@@ -0,0 +1,59 @@
+package day_five
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Single Translator mappings and composed chains should translate every id the same way
+// translateSeed does, and keep their segments sorted, non-overlapping, and normalized
+func TestComposeChain(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	for n := 0; n < randomChains; n++ {
+		translators := randomChain(random)
+		composed := ComposeChain(translators)
+		for id := randomIdMin; id < randomIdMax; id++ {
+			if got, want := composed.Translate(id), translateSeed(id, translators); got != want {
+				t.Fatalf("chain %d: composed %s\ntranslates %d to %d, want %d", n, composed,
+					id, got, want)
+			}
+		}
+		for i := range *translators {
+			translator := &(*translators)[i]
+			mapping := NewPiecewiseMapping(translator)
+			for id := randomIdMin; id < randomIdMax; id++ {
+				if got, want := mapping.Translate(id), translator.Translate(id); got != want {
+					t.Fatalf("chain %d: %s translates %d to %d, want %d", n, mapping, id, got, want)
+				}
+			}
+		}
+
+		segments := composed.Segments()
+		for i, segment := range segments {
+			if segment.Start >= segment.End || segment.Shift == 0 {
+				t.Fatalf("chain %d: empty or identity segment %+v", n, segment)
+			}
+			if i > 0 && segments[i-1].End > segment.Start {
+				t.Fatalf("chain %d: segments %+v and %+v overlap", n, segments[i-1], segment)
+			}
+			if i > 0 && segments[i-1].End == segment.Start && segments[i-1].Shift == segment.Shift {
+				t.Fatalf("chain %d: segments %+v and %+v should be merged", n, segments[i-1],
+					segment)
+			}
+		}
+	}
+}
+
+// Map names chain together when the categories line up
+func TestComposeNames(t *testing.T) {
+	cases := [][3]string{
+		{"seed-to-soil", "soil-to-water", "seed-to-water"},
+		{"", "soil-to-water", "soil-to-water"},
+		{"seed-to-soil", "water-to-light", "seed-to-soil then water-to-light"},
+	}
+	for _, c := range cases {
+		if got := composeNames(c[0], c[1]); got != c[2] {
+			t.Errorf("composeNames(%q, %q) = %q, want %q", c[0], c[1], got, c[2])
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package day_five
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Checks the almanac for problems that still parse: overlapping, non-injective, and
+// zero-length ranges in each map, and a broken seed-to-location chain. Every map gets a
+// line of coverage statistics. Used by the Day 5 Solver as its Linter.
+func LintDayFive(input *[]string) (*utility.LintReport, error) {
+	translators, err := initTranslators(input)
+	if err != nil {
+		return nil, err
+	}
+
+	report := utility.NewLintReport(5)
+	for i := range *translators {
+		(*translators)[i].Validate(report)
+	}
+
+	// the maps still need to chain together from seeds to locations
+	report.AddInfo("seed-to-location chain")
+	graph, err := NewCategoryGraph(translators)
+	if err == nil {
+		_, err = graph.Path("seed", "location")
+	}
+	if err != nil {
+		report.AddIssue("%s", err)
+	}
+	return report, nil
+}
+
+// Validates the Translator's ranges and adds the findings to report: overlapping source
+// ranges (Translate() silently uses the first match), overlapping destination ranges
+// (the mapping isn't one-to-one), zero-length ranges, and source coverage statistics
+func (t *Translator) Validate(report *utility.LintReport) {
+	fromRanges := make([][]int, 0)
+	toRanges := make([][]int, 0)
+	if t.fromRanges != nil && t.toRanges != nil {
+		fromRanges = *t.fromRanges
+		toRanges = *t.toRanges
+	}
+
+	covered, spanStart, spanEnd, gaps := rangeCoverage(fromRanges)
+	if len(fromRanges) == 0 {
+		report.AddInfo("%s map (line %d): no ranges - every id maps to itself",
+			t.TranslatorType, t.line)
+	} else {
+		report.AddInfo("%s map (line %d): %d ranges, %d of %d source ids in [%d, %d) mapped (%.1f%%), %d gaps",
+			t.TranslatorType, t.line, len(fromRanges), covered, spanEnd-spanStart, spanStart,
+			spanEnd, 100*float64(covered)/float64(max(spanEnd-spanStart, 1)), gaps)
+	}
+
+	for i, fromRange := range fromRanges {
+		if fromRange[0] == fromRange[1] {
+			report.AddIssue("line %d: zero-length range at source id %d", t.rangeLine(i),
+				fromRange[0])
+			continue
+		}
+		// compare against earlier ranges so each overlap is reported once
+		for j := 0; j < i; j++ {
+			if rangesOverlap(fromRanges[j], fromRange) {
+				report.AddIssue("line %d: source range [%d, %d) overlaps line %d [%d, %d) - line %d wins",
+					t.rangeLine(i), fromRange[0], fromRange[1], t.rangeLine(j),
+					fromRanges[j][0], fromRanges[j][1], t.rangeLine(j))
+			}
+			if rangesOverlap(toRanges[j], toRanges[i]) {
+				report.AddIssue("line %d: destination range [%d, %d) overlaps line %d [%d, %d) - mapping isn't one-to-one",
+					t.rangeLine(i), toRanges[i][0], toRanges[i][1], t.rangeLine(j),
+					toRanges[j][0], toRanges[j][1])
+			}
+		}
+	}
+}
+
+// Returns the input line of a range, or 0 when the range wasn't parsed from input
+func (t *Translator) rangeLine(i int) int {
+	if i < len(t.rangeLines) {
+		return t.rangeLines[i]
+	}
+	return 0
+}
+
+// Reports whether two non-empty [start, end) ranges share any ids
+func rangesOverlap(a []int, b []int) bool {
+	return a[0] < b[1] && b[0] < a[1] && a[0] < a[1] && b[0] < b[1]
+}
+
+// Measures how much of the span [min start, max end) a set of ranges covers. Returns the
+// number of covered ids, the span, and the number of uncovered gaps inside the span.
+// Zero-length ranges don't cover anything, so they don't stretch the span either.
+func rangeCoverage(ranges [][]int) (int, int, int, int) {
+	sorted := slices.Clone(ranges)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+
+	covered, gaps := 0, 0
+	spanStart, spanEnd := 0, 0
+	first := true
+	for _, idRange := range sorted {
+		if idRange[0] >= idRange[1] {
+			continue
+		}
+		if first {
+			spanStart, spanEnd = idRange[0], idRange[0]
+			first = false
+		}
+		if idRange[0] > spanEnd {
+			gaps++
+		}
+		// only count the ids past what's already covered
+		if idRange[1] > spanEnd {
+			covered += idRange[1] - max(idRange[0], spanEnd)
+			spanEnd = idRange[1]
+		}
+	}
+	return covered, spanStart, spanEnd, gaps
+}
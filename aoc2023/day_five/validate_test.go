@@ -0,0 +1,72 @@
+package day_five
+
+import (
+	"strings"
+	"testing"
+)
+
+// Zero-length ranges shouldn't stretch the span or add gaps
+func TestRangeCoverage(t *testing.T) {
+	cases := []struct {
+		name                                  string
+		ranges                                [][]int
+		covered, spanStart, spanEnd, gapCount int
+	}{
+		{"empty", [][]int{}, 0, 0, 0, 0},
+		{"one range", [][]int{{0, 55}}, 55, 0, 55, 0},
+		{"zero-length past the end", [][]int{{0, 55}, {100, 100}}, 55, 0, 55, 0},
+		{"zero-length first", [][]int{{-5, -5}, {10, 20}}, 10, 10, 20, 0},
+		{"gap and overlap", [][]int{{0, 10}, {5, 15}, {20, 30}}, 25, 0, 30, 1},
+	}
+	for _, c := range cases {
+		covered, spanStart, spanEnd, gaps := rangeCoverage(c.ranges)
+		if covered != c.covered || spanStart != c.spanStart || spanEnd != c.spanEnd ||
+			gaps != c.gapCount {
+			t.Errorf("%s: got %d ids in [%d, %d) with %d gaps, want %d ids in [%d, %d) with %d gaps",
+				c.name, covered, spanStart, spanEnd, gaps, c.covered, c.spanStart, c.spanEnd,
+				c.gapCount)
+		}
+	}
+}
+
+// The linter reports overlapping, non-injective, and zero-length ranges and a broken
+// chain, and finds nothing wrong with the example
+func TestLintDayFive(t *testing.T) {
+	input := []string{
+		"seeds: 1 2",
+		"seed-to-soil map:",
+		"50 98 2", // line 3
+		"52 99 5", // source overlaps line 3
+		"60 10 0", // zero-length
+		"51 20 3", // destination overlaps lines 3 and 4
+		"soil-to-water map:",
+	}
+	report, err := LintDayFive(&input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lint := strings.Join(report.Lines, "\n")
+	findings := []string{
+		"9 of 84 source ids in [20, 104) mapped",
+		"soil-to-water map (line 7): no ranges",
+		"line 4: source range [99, 104) overlaps line 3 [98, 100) - line 3 wins",
+		"line 5: zero-length range at source id 10",
+		"line 6: destination range [51, 54) overlaps line 3 [50, 52)",
+		"line 6: destination range [51, 54) overlaps line 4 [52, 57)",
+		`unknown category "location"`,
+	}
+	for _, finding := range findings {
+		if !strings.Contains(lint, finding) {
+			t.Errorf("lint report missing %q:\n%s", finding, lint)
+		}
+	}
+	if report.Issues != 5 {
+		t.Errorf("lint issues = %d, want 5:\n%s", report.Issues, lint)
+	}
+
+	example := readInput(t, "../data/day_five_ex.txt")
+	report, err = LintDayFive(&example)
+	if err != nil || report.Issues != 0 {
+		t.Errorf("example lint = %v, %v, want no issues", report, err)
+	}
+}
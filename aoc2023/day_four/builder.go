@@ -1,10 +1,11 @@
 package day_four
 
 import (
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
 )
 
 /*
@@ -12,69 +13,155 @@ Interface for implementations of the Builder Design pattern to create a GameCard
 collection object.
 */
 type DeckBuilder interface {
-	BuildCard(string)
+	BuildCard(string) error
 	GetCollection() *GameCardDeck
 }
 
 /*
 Concrete data structure for the implementation of the DeckBuilder interface. Supports
 building GameCardDeck with different WinBehavior objects - such as PointWinBehavior or
-CardCopyWinBehavior,
+CardCopyWinBehavior - picked by a registered WinBehavior name with optional parameters,
+ex. "points:base=2"
 */
 type DeckBuilderConcrete struct {
 	winBehaviorType string
 	deck            *GameCardDeck
+	cardBehavior    CardBehaviorFunc // creates each card's WinBehavior for the deck
+}
+
+// Constructor creates a DeckBuilderConcrete for a WinBehavior type. Returns an error if
+// the WinBehavior or its parameters aren't supported.
+func NewDeckBuilder(winBehaviorType string) (*DeckBuilderConcrete, error) {
+	builder := &DeckBuilderConcrete{winBehaviorType: winBehaviorType, deck: newGameCardDeck()}
+	if err := builder.initCardBehavior(); err != nil {
+		return nil, err
+	}
+	return builder, nil
 }
 
 // Takes an input string, creates WinBehavior, and creates GameCard with that behavior.
-// iteratively adds the new object to the GameCardDeck that is being built
-func (b *DeckBuilderConcrete) BuildCard(cardInputStr string) {
-	// Handle initializing the deck
-	if b.deck == nil {
-		// initialize GameCardDeck an empty list of GameCards
-		deckCards := make([]GameCard, 0)
-		deck := &GameCardDeck{
-			cards: &deckCards,
-		}
-		// set deck in builder
-		b.deck = deck
+// iteratively adds the new object to the GameCardDeck that is being built. Returns a
+// ParseError (without a line number) if the input string isn't a valid card
+func (b *DeckBuilderConcrete) BuildCard(cardInputStr string) error {
+	line, err := parseCardLine(cardInputStr)
+	if err != nil {
+		return err
 	}
+	// scratched numbers are kept as strings and parsed each time the card is scored
+	gameCard := GameCard{cardId: line.cardId, winMap: line.winMap, numStrings: &line.numStrings}
+	return b.addCard(gameCard, line.idColumn)
+}
 
+/*
+Fields of a "Card N: winning numbers | scratched numbers" input line. idColumn is the
+1-based column of the card id, for errors about the card as a whole.
+*/
+type cardLine struct {
+	cardId     int
+	idColumn   int
+	winMap     *map[int]bool
+	numStrings []string
+	numbers    *[]int
+}
+
+// Splits a "Card N: ... | ..." input string into its card id, winning numbers, and
+// scratched numbers. Returns a ParseError (without a line number) if the input string
+// isn't a valid card
+func parseCardLine(cardInputStr string) (*cardLine, error) {
 	// split Card # from numbers on ":"
-	allNumbers := strings.Split(cardInputStr, ":")
+	allNumbers := strings.SplitN(cardInputStr, ":", 2)
+	if len(allNumbers) != 2 {
+		return nil, utility.NewParseError(0, 0, "missing \":\" after card id", nil)
+	}
 	// Grab id
-	numReg := regexp.MustCompile("\\d+")
-	idStr := numReg.FindString(allNumbers[0])
+	idIndices := cardIdReg.FindStringIndex(allNumbers[0])
+	if idIndices == nil {
+		return nil, utility.NewParseError(0, 1, "missing card id", nil)
+	}
+	idStr := allNumbers[0][idIndices[0]:idIndices[1]]
 	gameCardId, err := strconv.Atoi(idStr)
 	if err != nil {
-		fmt.Println("[ERROR] Problem encountered parsing gamecard id \"", idStr, "\"")
-		fmt.Println("[ERROR] Error details: ", err)
+		return nil, utility.NewParseError(0, idIndices[0]+1, "problem parsing gamecard id", err)
 	}
 
 	// Grab scratched off and winning numbers
 	// split winning #'s from scratched numbers on "|"
 	numbers := strings.Split(allNumbers[1], "|")
+	if len(numbers) != 2 {
+		return nil, utility.NewParseError(0, len(allNumbers[0])+2,
+			"expected winning numbers and scratched numbers separated by one \"|\"", nil)
+	}
+
+	// Grab a list of winning numbers and map it - columns are counted from the ":"
+	winOffset := len(allNumbers[0]) + 1
+	winNumStrings, winColumns := utility.FieldsWithColumns(numbers[0], winOffset)
+	winMap, err := mapWinningNumbers(&winNumStrings, &winColumns)
+	if err != nil {
+		return nil, err
+	}
+	// Grab scratched off numbers - check they parse now so scoring can't fail later
+	scratchedNumStrings, scratchedColumns := utility.FieldsWithColumns(numbers[1],
+		winOffset+len(numbers[0])+1)
+	scratchedNumbers, err := utility.ListAtoi(&scratchedNumStrings, &scratchedColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardLine{cardId: gameCardId, idColumn: idIndices[0] + 1, winMap: winMap,
+		numStrings: scratchedNumStrings, numbers: scratchedNumbers}, nil
+}
+
+// matches the card id in "Card N"
+var cardIdReg = regexp.MustCompile(`\d+`)
+
+// Helper method that gives a GameCard its WinBehavior and adds it to the GameCardDeck
+// being built. Returns a ParseError at column (without a line number) for duplicate ids
+func (b *DeckBuilderConcrete) addCard(gameCard GameCard, column int) error {
+	// Handle initializing the deck
+	if b.deck == nil {
+		// initialize GameCardDeck an empty list of GameCards and set deck in builder
+		b.deck = newGameCardDeck()
+	}
+	if err := b.initCardBehavior(); err != nil {
+		return err
+	}
+
+	// create WinBehavior for GameCard and add it to GameCardDeck being built
+	gameCard.winBehavior = b.createWinBehavior(gameCard.cardId)
+	if err := b.deck.add(gameCard); err != nil {
+		return utility.NewParseError(0, column, err.Error(), nil)
+	}
+	return nil
+}
+
+/*
+DeckBuilder for the "Card N: ... | ..." input format that parses scratched numbers into
+integers once, when the card is built, instead of every time the card is scored. Reuses
+DeckBuilderConcrete for the deck and WinBehavior bookkeeping.
+*/
+type IntDeckBuilder struct {
+	DeckBuilderConcrete
+}
 
-	// Grab a list of winning numbers and map it
-	winNumStrings := numReg.FindAllString(numbers[0], -1)
-	winMap := mapWinningNumbers(&winNumStrings)
-	// Grab scratched off numbers
-	scratchedNumStrings := numReg.FindAllString(numbers[1], -1)
-
-	// create WinBehavior for GameCard
-	var winBehavior *WinBehavior
-	winBehavior = b.createWinBehavior(gameCardId)
-
-	// Create GameCard and add to GameCardDeck being built
-	gameCard := &GameCard{
-		cardId:      gameCardId,
-		winMap:      winMap,
-		numStrings:  &scratchedNumStrings,
-		winBehavior: winBehavior,
-	}
-	gameCards := *b.deck.cards
-	gameCards = append(gameCards, *gameCard)
-	b.deck.cards = &gameCards
+// Constructor creates an IntDeckBuilder for a WinBehavior type. Returns an error if the
+// WinBehavior or its parameters aren't supported.
+func NewIntDeckBuilder(winBehaviorType string) (*IntDeckBuilder, error) {
+	builder, err := NewDeckBuilder(winBehaviorType)
+	if err != nil {
+		return nil, err
+	}
+	return &IntDeckBuilder{DeckBuilderConcrete: *builder}, nil
+}
+
+// Same as DeckBuilderConcrete.BuildCard(), but the GameCard keeps its scratched numbers
+// as integers
+func (b *IntDeckBuilder) BuildCard(cardInputStr string) error {
+	line, err := parseCardLine(cardInputStr)
+	if err != nil {
+		return err
+	}
+	gameCard := GameCard{cardId: line.cardId, winMap: line.winMap, numbers: line.numbers}
+	return b.addCard(gameCard, line.idColumn)
 }
 
 // Returns the built GameCardDeck collection
@@ -82,47 +169,64 @@ func (b *DeckBuilderConcrete) GetCollection() *GameCardDeck {
 	return b.deck
 }
 
-// Helper method that creates the WinBehavior. WinBehavior type is determined from the
-// builders winBehaviorType attribute. Returns nil if type is unsupported
-func (b *DeckBuilderConcrete) createWinBehavior(id int) *WinBehavior {
-	var winBehavior WinBehavior
-
-	// Pick WinBehavior from winBehaviorType attribute
-	switch b.winBehaviorType {
-	case "points":
-		// this is a win behavior that scores based on a geometric sequence with first
-		// term a=1 and common ratio (base) r=2
-		winBehavior = &PointsWinBehavior{
-			base: 2,
-		}
-	case "cards":
-		// this win behavior calculates score as the sum of the number of cards won from
-		// a single card and recursively the sum of the number of cards won from those
-		// won cards
-		winBehavior = &CardCopyWinBehavior{
-			gameCardId: id,
-			cardDeck:   b.deck,
-		}
-	default:
-		fmt.Println("[ERROR] WinBehavior type: ", b.winBehaviorType, " not supported")
+// Helper method that looks up the builder's winBehaviorType in the WinBehavior registry
+// (once per deck). Returns an error if the type or its parameters aren't supported
+func (b *DeckBuilderConcrete) initCardBehavior() error {
+	if b.cardBehavior != nil {
+		return nil
+	}
+	cardBehavior, err := NewCardBehavior(b.deck, b.winBehaviorType)
+	if err != nil {
+		return err
 	}
+	b.cardBehavior = cardBehavior
+	return nil
+}
+
+// Helper method that creates the WinBehavior for a card
+func (b *DeckBuilderConcrete) createWinBehavior(id int) *WinBehavior {
+	winBehavior := b.cardBehavior(id)
 	return &winBehavior
 }
 
+// Creates a copy of a built GameCardDeck where every GameCard gets a new WinBehavior of
+// winBehaviorType. Cards share their parsed numbers with the original deck, so a deck
+// can be parsed once and scored with different behaviors. Returns an error if the
+// WinBehavior type isn't supported.
+func RebuildWithWinBehavior(deck *GameCardDeck, winBehaviorType string) (*GameCardDeck, error) {
+	// init the new deck and a builder aware of it (CardCopyWinBehavior needs the deck).
+	// Card positions don't change, so the id index is shared
+	gameCards := make([]GameCard, len(*deck.cards))
+	missing := make(map[int]bool)
+	gaps := make(map[int]bool)
+	newDeck := &GameCardDeck{cards: &gameCards, index: deck.index, maxId: deck.maxId,
+		missing: &missing, gaps: &gaps, overflowPolicy: deck.overflowPolicy}
+	builder := &DeckBuilderConcrete{winBehaviorType: winBehaviorType, deck: newDeck}
+	if err := builder.initCardBehavior(); err != nil {
+		return nil, err
+	}
+
+	// copy each card with a new WinBehavior
+	for i, gameCard := range *deck.cards {
+		gameCard.winBehavior = builder.createWinBehavior(gameCard.cardId)
+		gameCards[i] = gameCard
+	}
+	return newDeck, nil
+}
+
 // Function takes a list of winning number strings and returns a simple string: true map
 // This allows O(n) lookups to see if a scratched off number matches a winning number.
-func mapWinningNumbers(winNumStrings *[]string) *map[int]bool {
+// Returns a ParseError at the number's column if a number string can't be parsed
+func mapWinningNumbers(winNumStrings *[]string, columns *[]int) (*map[int]bool, error) {
 	// iterate through list of number strings - map winning numbers to keys
 	winMap := make(map[int]bool)
-	for _, numStr := range *winNumStrings {
-		num, err := strconv.Atoi(numStr)
-		if err != nil {
-			fmt.Println("[ERROR] Error parsing: \"", numStr, "\" error: ", err)
-		} else {
-			// no error - normal case
-			winMap[num] = true
-		}
+	winNumbers, err := utility.ListAtoi(winNumStrings, columns)
+	if err != nil {
+		return nil, err
+	}
+	for _, num := range *winNumbers {
+		winMap[num] = true
 	}
 
-	return &winMap
+	return &winMap, nil
 }
@@ -0,0 +1,118 @@
+package day_four
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Converts "Card N: ... | ..." input lines into JSON deck lines
+func jsonDeckLines(tb testing.TB, input []string) []string {
+	jsonLines := make([]string, len(input))
+	for i, inputStr := range input {
+		line, err := parseCardLine(inputStr)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		card := jsonCard{Id: &line.cardId, Winning: (&GameCard{winMap: line.winMap}).winningNumbers(),
+			Numbers: *line.numbers}
+		jsonLine, err := json.Marshal(card)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		jsonLines[i] = string(jsonLine)
+	}
+	return jsonLines
+}
+
+// Every DeckBuilder should build decks that score the same for both parts
+func TestDeckBuilders(t *testing.T) {
+	for _, filepath := range []string{"../data/day_four_ex.txt", "../data/day_four_input.txt"} {
+		content, err := os.ReadFile(filepath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		input := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		inputs := map[string][]string{"text": input, "ints": input, "json": jsonDeckLines(t, input)}
+
+		scores := make(map[string][2]int)
+		for name, newBuilder := range deckBuilders {
+			builder, err := newBuilder("points")
+			if err != nil {
+				t.Fatal(err)
+			}
+			builderInput := inputs[name]
+			deck, err := NewDeckDirector(builder).Construct(&builderInput)
+			if err != nil {
+				t.Fatalf("%s %s: %v", filepath, name, err)
+			}
+			scores[name] = [2]int{countCards(deck), countCards(rebuildDeck(t, deck, "cards"))}
+		}
+		for name, score := range scores {
+			if score != scores["text"] {
+				t.Errorf("%s: %s builder scored %v, text builder %v", filepath, name, score,
+					scores["text"])
+			}
+		}
+	}
+}
+
+// JSON cards that can't be built should be ParseErrors on their line
+func TestJsonDeckBuilderErrors(t *testing.T) {
+	cases := map[string]string{
+		"invalid json":     `{"id": 1, "winning": [1, 2]`,
+		"unknown field":    `{"id": 1, "winning": [1], "numbers": [1], "extra": true}`,
+		"missing id":       `{"winning": [1], "numbers": [1]}`,
+		"two objects":      `{"id": 1} {"id": 2}`,
+		"trailing bracket": `{"id": 3, "winning": [1], "numbers": [1]} ]`,
+		"duplicate card":   `{"id": 1, "winning": [1], "numbers": [1]}`,
+	}
+	for name, badLine := range cases {
+		builder, err := NewJsonDeckBuilder("points")
+		if err != nil {
+			t.Fatal(err)
+		}
+		input := []string{`{"id": 1, "winning": [1], "numbers": [2]}`, badLine}
+		_, err = NewDeckDirector(builder).Construct(&input)
+		var parseErr *utility.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+			t.Errorf("%s: got %v, want a ParseError on line 2", name, err)
+		}
+	}
+}
+
+// Bad numbers in a text card are reported at their line and column
+func TestDeckBuilderErrorColumns(t *testing.T) {
+	cases := map[string]int{
+		"Card 1: 41 x8 | 83 86": 12,
+		"Card 1: 41 48 | 83 8y": 20,
+	}
+	for badLine, column := range cases {
+		input := []string{"Card 2: 1 | 1", badLine}
+		_, err := ConstructGameCardDeck(&DeckBuilderConcrete{winBehaviorType: "points"},
+			&input)
+		var parseErr *utility.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Column != column {
+			t.Errorf("%q: got %v, want a ParseError at 2:%d", badLine, err, column)
+		}
+	}
+}
+
+// Duplicate JSON card ids point at the id key, whatever its case
+func TestJsonDeckBuilderDuplicateColumn(t *testing.T) {
+	builder, err := NewJsonDeckBuilder("points")
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := []string{`{"id": 1, "winning": [1], "numbers": [2]}`,
+		`{"numbers": [1], "ID": 1, "winning": [1]}`}
+	_, err = NewDeckDirector(builder).Construct(&input)
+	var parseErr *utility.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Column != 18 {
+		t.Errorf("got %v, want a ParseError at 2:18", err)
+	}
+}
@@ -1,8 +1,11 @@
 package day_four
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 /*
@@ -11,11 +14,13 @@ Data structure to store the values for a given game card including:
 - game card winning numbers (that the numbers the card has have to match)
 - game card numbers (these have to match winning numbers to get a score)
 - a WinBehavior object - abstracts how scoring is calculated
+Game card numbers are either strings parsed when scoring, or pre-parsed integers.
 */
 type GameCard struct {
 	cardId      int
 	winMap      *map[int]bool
 	numStrings  *[]string
+	numbers     *[]int // pre-parsed numbers - used instead of numStrings when set
 	winBehavior *WinBehavior
 }
 
@@ -42,42 +47,168 @@ func (c *GameCard) matchCount() int {
 	matchCount := 0
 	winMap := *c.winMap
 
+	// pre-parsed numbers skip the string parsing
+	if c.numbers != nil {
+		for _, num := range *c.numbers {
+			if winMap[num] {
+				matchCount += 1
+			}
+		}
+		return matchCount
+	}
+
 	// iterate through GameCard numbers - increment count on matches to winning numbers
 	for _, numStr := range *c.numStrings {
 		// init match flag
 		match := false
 		// parse number string into int
-		num, err := strconv.Atoi(numStr)
-		if err != nil {
-			fmt.Println("[ERROR] Error parsing: \"", numStr, "\" error: ", err)
-		} else {
-			// no error - normal case
-			match = winMap[num]
-			if match {
-				// match found! let's increment the match counter
-				matchCount += 1
-			}
+		// note: number strings are validated when the card is built, so this can't fail
+		num, _ := strconv.Atoi(numStr)
+		match = winMap[num]
+		if match {
+			// match found! let's increment the match counter
+			matchCount += 1
 		}
 	}
 
 	return matchCount
 }
 
+// Returns the GameCard's scratched numbers as integers, in the order they appear on the card
+func (c *GameCard) scratchedNumbers() []int {
+	if c.numbers != nil {
+		return *c.numbers
+	}
+	numbers := make([]int, len(*c.numStrings))
+	for i, numStr := range *c.numStrings {
+		// note: number strings are validated when the card is built, so this can't fail
+		numbers[i], _ = strconv.Atoi(numStr)
+	}
+	return numbers
+}
+
+// Policy for cards won past the final card of a deck, ex. card 10 of 10 with 2 matches
+type OverflowPolicy int
+
+const (
+	// Report wins past the final card as an error from the solver
+	OverflowReport OverflowPolicy = iota
+	// Clamp wins to the cards that exist - missing cards are ignored
+	OverflowClamp
+)
+
+// Parses an overflow policy name: "report" or "clamp"
+func ParseOverflowPolicy(policyStr string) (OverflowPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(policyStr)) {
+	case "report":
+		return OverflowReport, nil
+	case "clamp":
+		return OverflowClamp, nil
+	}
+	return OverflowReport, fmt.Errorf("overflow policy %q not supported: use report or clamp",
+		policyStr)
+}
+
+// Returned (wrapped) by GameCardDeck.Get() for ids that aren't in the deck
+var ErrCardNotFound = errors.New("card not found")
+
 /*
-Collection of GameCards
+Collection of GameCards. Cards are looked up by their card id, so ids don't have to be
+contiguous or in order. Won card ids past the final (highest) card id are tracked
+according to the deck's OverflowPolicy. Won ids in a gap between card ids just don't
+win a card - they're tracked separately and never an error.
 */
 type GameCardDeck struct {
-	cards *[]GameCard
+	cards          *[]GameCard
+	index          *map[int]int // card id: position in cards
+	maxId          int          // final card id
+	overflowPolicy OverflowPolicy
+	missing        *map[int]bool // won card ids past the final card
+	gaps           *map[int]bool // won card ids in a gap between card ids
+}
+
+// Constructor creates an empty GameCardDeck that reports wins past the final card
+func newGameCardDeck() *GameCardDeck {
+	cards := make([]GameCard, 0)
+	index := make(map[int]int)
+	missing := make(map[int]bool)
+	gaps := make(map[int]bool)
+	return &GameCardDeck{cards: &cards, index: &index, missing: &missing, gaps: &gaps,
+		overflowPolicy: OverflowReport}
+}
+
+// Fetches the GameCard with a card id from the GameCardDeck collection. Returns an error
+// wrapping ErrCardNotFound if the deck doesn't have that card
+func (d *GameCardDeck) Get(id int) (*GameCard, error) {
+	position, found := (*d.index)[id]
+	if !found {
+		return nil, fmt.Errorf("card %d: %w", id, ErrCardNotFound)
+	}
+	return &(*d.cards)[position], nil
+}
+
+// Number of cards in the deck
+func (d *GameCardDeck) Len() int {
+	return len(*d.cards)
+}
+
+// Sets how wins past the final card are handled
+func (d *GameCardDeck) SetOverflowPolicy(policy OverflowPolicy) {
+	d.overflowPolicy = policy
+}
+
+// Adds a GameCard to the deck. Returns an error if the deck already has the card id
+func (d *GameCardDeck) add(gameCard GameCard) error {
+	if _, found := (*d.index)[gameCard.cardId]; found {
+		return fmt.Errorf("duplicate card id %d", gameCard.cardId)
+	}
+	(*d.index)[gameCard.cardId] = len(*d.cards)
+	*d.cards = append(*d.cards, gameCard)
+	d.maxId = max(d.maxId, gameCard.cardId)
+	return nil
+}
+
+// Fetches a won card for a copy behavior. Missing cards are reported as not found and
+// recorded: ids past the final card for the overflow policy, the rest as gaps
+func (d *GameCardDeck) wonCard(id int) (*GameCard, bool) {
+	gameCard, err := d.Get(id)
+	if err != nil {
+		if id > d.maxId {
+			(*d.missing)[id] = true
+		} else {
+			(*d.gaps)[id] = true
+		}
+		return nil, false
+	}
+	return gameCard, true
+}
+
+// Won card ids past the final card, sorted
+func (d *GameCardDeck) MissingWins() []int {
+	return sortedIds(d.missing)
+}
+
+// Won card ids in a gap between the deck's card ids, sorted
+func (d *GameCardDeck) GapWins() []int {
+	return sortedIds(d.gaps)
+}
+
+// Helper function returns the ids in a set, sorted
+func sortedIds(idSet *map[int]bool) []int {
+	ids := make([]int, 0, len(*idSet))
+	for id := range *idSet {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
 }
 
-// Fetches the GameCard from the GameCardDeck collection. Returns nil if not found
-func (d *GameCardDeck) Get(id int) *GameCard {
-	gameCards := *d.cards
-	// handle bad input
-	if id < 0 || id > len(gameCards) {
+// Returns an error listing won cards past the final card when the overflow policy is
+// OverflowReport. Clamped decks never return an error.
+func (d *GameCardDeck) OverflowErr() error {
+	missing := d.MissingWins()
+	if d.overflowPolicy == OverflowClamp || len(missing) == 0 {
 		return nil
 	}
-	// fetch and return
-	gameCard := gameCards[id-1]
-	return &gameCard
+	return fmt.Errorf("won cards %v past the final card: %w", missing, ErrCardNotFound)
 }
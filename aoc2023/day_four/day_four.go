@@ -2,7 +2,12 @@ package day_four
 
 import (
 	"fmt"
+	"io"
+	"slices"
 	"strconv"
+	"strings"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
 )
 
 type MatchVisitor struct {
@@ -11,65 +16,221 @@ type MatchVisitor struct {
 	points int
 }
 
-// High level entry Point for Day 4 solution
-func SolveDayFour(input *[]string, part int) {
+/*
+Day 4 settings: the WinBehavior for each part, the DeckBuilder for the input (detected
+from the input when empty), and the policy for cards won past the final card
+*/
+type DayFourSettings struct {
+	WinBehaviors   map[int]string
+	DeckBuilder    string
+	OverflowPolicy OverflowPolicy
+}
+
+// Default WinBehavior for each part
+var partWinBehaviors = map[int]string{1: "points", 2: "cards"}
 
-	if part == 1 {
-		solvePartOne(input)
-	} else if part == 2 {
-		solvePartTwo(input)
-	} else {
-		fmt.Println("Part: " + strconv.Itoa(part) + "Not supported")
+// Settings used by ParseDayFour: points for part 1, cards for part 2, the DeckBuilder
+// detected from the input, and wins past the final card reported as an error
+func DefaultDayFourSettings() DayFourSettings {
+	winBehaviors := make(map[int]string, len(partWinBehaviors))
+	for part, winBehaviorType := range partWinBehaviors {
+		winBehaviors[part] = winBehaviorType
 	}
+	return DayFourSettings{WinBehaviors: winBehaviors, OverflowPolicy: OverflowReport}
+}
 
+/*
+Day 4 Solver: wraps ParseDayFourWith for a set of settings and adds the optional
+Exporter for per-card reports and the Configurable settings
+*/
+type scratchcardsSolver struct {
+	utility.Solver
+	settings DayFourSettings
 }
 
-// Entry point for day 4 part 1 solution
-func solvePartOne(input *[]string) {
-	fmt.Println("--- Solving Day Four - Part One! ---")
+// Constructor creates a Day 4 Solver that parses and solves with settings
+func newScratchcardsSolver(settings DayFourSettings) scratchcardsSolver {
+	parse := func(input *[]string) (utility.Puzzle, error) {
+		return ParseDayFourWith(input, settings)
+	}
+	return scratchcardsSolver{Solver: utility.NewSolver(4, []int{1, 2}, parse),
+		settings: settings}
+}
 
-	// Build builder
-	var deckBuilder DeckBuilder
-	deckBuilder = &DeckBuilderConcrete{winBehaviorType: "points"}
-	// Call Construct (usually abstracted in a Director interface)
-	deck := ConstructGameCardDeck(deckBuilder, input)
+// Exporter.Export() implementation: reports the deck parsed with the Solver's settings
+func (s scratchcardsSolver) Export(writer io.Writer, input *[]string,
+	opts utility.ExportOptions) error {
+	puzzle, err := s.Parse(input)
+	if err != nil {
+		return err
+	}
+	return writeDeckReport(writer, puzzle.(*scratchcards).deck, opts)
+}
 
-	// Iterate through cards in collection and get points
-	points := 0
-	for _, gameCard := range *deck.cards {
-		points += gameCard.Win()
+// Configurable.Settings() implementation: a win-behavior.N setting per part, plus the
+// deck-builder and overflow settings
+func (s scratchcardsSolver) Settings() []utility.Setting {
+	settings := make([]utility.Setting, 0)
+	for _, part := range s.Parts() {
+		settings = append(settings, utility.Setting{
+			Name: fmt.Sprintf("win-behavior.%d", part),
+			Usage: fmt.Sprintf("WinBehavior for part %d, ex. points:base=3, linear:per=2, or "+
+				"cards:cap=2 (default: %s)", part, partWinBehaviors[part]),
+		})
 	}
-	fmt.Println("Sum of win points: ", points)
+	return append(settings,
+		utility.Setting{
+			Name: "deck-builder",
+			Usage: "DeckBuilder for the input: text (numbers parsed when scoring), ints " +
+				"(numbers parsed once), or json (one card object per line) (default: json or " +
+				"ints by input)",
+		},
+		utility.Setting{
+			Name:  "overflow",
+			Usage: "cards won past the final card: report (an error) or clamp (ignore them)",
+		})
 }
 
-// Entry point for day 4 part 2 solution
-func solvePartTwo(input *[]string) {
-	fmt.Println("--- Solving Day Four - Part Two! ---")
+// Configurable.Configure() implementation: returns a Day 4 Solver with the settings
+// applied on top of this Solver's settings. Values are checked now rather than at
+// solve time.
+func (s scratchcardsSolver) Configure(values map[string]string) (utility.Solver, error) {
+	settings := s.settings
+	settings.WinBehaviors = make(map[int]string, len(s.settings.WinBehaviors))
+	for part, winBehaviorType := range s.settings.WinBehaviors {
+		settings.WinBehaviors[part] = winBehaviorType
+	}
+
+	for name, value := range values {
+		var err error
+		switch {
+		case name == "deck-builder":
+			if _, found := deckBuilders[value]; !found {
+				err = fmt.Errorf("DeckBuilder %q not supported: use text, ints, or json", value)
+			}
+			settings.DeckBuilder = value
+		case name == "overflow":
+			settings.OverflowPolicy, err = ParseOverflowPolicy(value)
+		case strings.HasPrefix(name, "win-behavior."):
+			part, convErr := strconv.Atoi(strings.TrimPrefix(name, "win-behavior."))
+			if convErr != nil || !slices.Contains(s.Parts(), part) {
+				return nil, fmt.Errorf("unknown setting %q: use win-behavior.N for part N", name)
+			}
+			_, err = NewCardBehavior(newGameCardDeck(), value)
+			settings.WinBehaviors[part] = value
+		default:
+			return nil, fmt.Errorf("unknown setting %q", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("setting %s: %w", name, err)
+		}
+	}
+	return newScratchcardsSolver(settings), nil
+}
+
+// Day 4 Solver - registered with the central Solver registry
+var dayFourSolver = newScratchcardsSolver(DefaultDayFourSettings())
 
-	// Build builder
-	var deckBuilder DeckBuilder
-	deckBuilder = &DeckBuilderConcrete{winBehaviorType: "cards"}
-	// Call Construct (usually abstracted in a Director interface)
-	deck := ConstructGameCardDeck(deckBuilder, input)
+func init() {
+	utility.RegisterSolver(dayFourSolver)
+}
+
+// DeckBuilder constructors by name, ex. for the deck-builder setting
+var deckBuilders = map[string]func(winBehaviorType string) (DeckBuilder, error){
+	"text": func(winBehaviorType string) (DeckBuilder, error) { return NewDeckBuilder(winBehaviorType) },
+	"ints": func(winBehaviorType string) (DeckBuilder, error) { return NewIntDeckBuilder(winBehaviorType) },
+	"json": func(winBehaviorType string) (DeckBuilder, error) { return NewJsonDeckBuilder(winBehaviorType) },
+}
+
+// Picks the DeckBuilder for the parsed input: name if set, otherwise json for JSON decks
+// and ints (pre-parsed numbers) for "Card N: ... | ..." text
+func newInputDeckBuilder(input *[]string, name string) (DeckBuilder, error) {
+	if len(name) == 0 {
+		name = "ints"
+		if isJsonDeck(input) {
+			name = "json"
+		}
+	}
+	return deckBuilders[name]("points")
+}
+
+// Parsed Day 4 input: the deck of GameCards and the settings to solve it with
+type scratchcards struct {
+	deck     *GameCardDeck
+	settings DayFourSettings
+}
+
+// Parses the Day 4 input into a GameCardDeck shared by both parts with the default
+// settings
+func ParseDayFour(input *[]string) (utility.Puzzle, error) {
+	return ParseDayFourWith(input, DefaultDayFourSettings())
+}
+
+// Parses the Day 4 input into a GameCardDeck shared by both parts. Each part rebuilds
+// the deck with its WinBehavior from settings
+func ParseDayFourWith(input *[]string, settings DayFourSettings) (utility.Puzzle, error) {
+	// Build builder for the input format
+	deckBuilder, err := newInputDeckBuilder(input, settings.DeckBuilder)
+	if err != nil {
+		return nil, err
+	}
+	deck, err := NewDeckDirector(deckBuilder).Construct(input)
+	if err != nil {
+		return nil, err
+	}
+
+	deck.SetOverflowPolicy(settings.OverflowPolicy)
+
+	return &scratchcards{deck: deck, settings: settings}, nil
+}
+
+// Puzzle.Solve() implementation: solves a part against the shared parsed input
+func (p *scratchcards) Solve(part int) (*utility.Result, error) {
+	if part == 1 || part == 2 {
+		return solvePart(p.deck, part, p.settings.WinBehaviors[part])
+	}
+	return nil, fmt.Errorf("part %d not supported", part)
+}
+
+// High level entry point: parses the input and solves a single part
+func SolveDayFour(input *[]string, part int) (*utility.Result, error) {
+	return dayFourSolver.Solve(input, part)
+}
+
+// Entry point for day 4 part 1 and 2 solutions: rebuilds the parsed deck with the part's
+// WinBehavior and sums every card's score. Returns an error for cards won past the final
+// card unless the deck's overflow policy clamps them
+func solvePart(parsedDeck *GameCardDeck, part int, winBehaviorType string) (*utility.Result,
+	error) {
+	// Rebuild the parsed deck with the part's scoring
+	deck, err := RebuildWithWinBehavior(parsedDeck, winBehaviorType)
+	if err != nil {
+		return nil, err
+	}
 
 	// Iterate through cards in collection and get points
 	points := 0
 	for _, gameCard := range *deck.cards {
 		points += gameCard.Win()
 	}
-	fmt.Println("Sum of win points: ", points)
+	if err := deck.OverflowErr(); err != nil {
+		return nil, err
+	}
+	result := utility.NewResult(4, part, "Sum of win points", points)
+	result.AddDiagnostic("cards in deck: " + strconv.Itoa(len(*deck.cards)))
+	if winBehaviorType != partWinBehaviors[part] {
+		result.AddDiagnostic("win behavior: " + winBehaviorType)
+	}
+	if missing := deck.MissingWins(); len(missing) > 0 {
+		result.AddDiagnostic(fmt.Sprintf("clamped wins past the final card: %v", missing))
+	}
+	if gaps := deck.GapWins(); len(gaps) > 0 {
+		result.AddDiagnostic(fmt.Sprintf("won card ids missing from the deck: %v", gaps))
+	}
+	return result, nil
 }
 
-// Utility function acts as the Director with a Construct. In more complex
-// constructions, this should be abstracted as a class (or an interface in Go)
-// However, since this level of complexity is unneeded, in go fashion we are implemnting
-// this component of the Design pattern as a utility function
-func ConstructGameCardDeck(builder DeckBuilder, input *[]string) *GameCardDeck {
-	// Iterate through input and build a card
-	// builder := *builderPtr
-	for _, inputStr := range *input {
-		builder.BuildCard(inputStr)
-	}
-	// Finished calling BuildCard() from input - get build GameCardDeck
-	return builder.GetCollection()
+// Convenience wrapper that constructs a GameCardDeck from input with a DeckDirector
+func ConstructGameCardDeck(builder DeckBuilder, input *[]string) (*GameCardDeck, error) {
+	return NewDeckDirector(builder).Construct(input)
 }
@@ -0,0 +1,46 @@
+package day_four
+
+import (
+	"testing"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Settings only change the configured Solver and the part they name
+func TestConfigureDayFour(t *testing.T) {
+	input := []string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+	}
+	configured, err := dayFourSolver.Configure(map[string]string{
+		"win-behavior.1": "points:base=3",
+		"overflow":       "clamp",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := utility.SolveParts(configured, &input, []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// card 1: 4 matches, card 2: 2 matches, with points base 3 for part 1 only
+	if results[0].Answer != 27+3 || results[1].Answer != 3 {
+		t.Errorf("configured answers = %d, %d, want 30, 3", results[0].Answer,
+			results[1].Answer)
+	}
+	// card 1 wins cards 2 to 5 - only the configured Solver clamps them
+	if _, err := utility.SolveParts(dayFourSolver, &input, []int{2}); err == nil {
+		t.Error("registered Solver should still report wins past the final card")
+	}
+
+	for _, values := range []map[string]string{
+		{"win-behavior": "points"},
+		{"win-behavior.3": "points"},
+		{"win-behavior.2": "bogus"},
+		{"deck-builder": "xml"},
+	} {
+		if _, err := dayFourSolver.Configure(values); err == nil {
+			t.Errorf("%v should be an error", values)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package day_four
+
+import (
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+Director for the Builder Design pattern. Drives a DeckBuilder through an input, one card
+per line, so the same construction works with any DeckBuilder - the "Card N: ... | ..."
+text format (DeckBuilderConcrete, IntDeckBuilder) or JSON cards (JsonDeckBuilder).
+*/
+type DeckDirector struct {
+	builder DeckBuilder
+}
+
+// Constructor creates a DeckDirector for a DeckBuilder
+func NewDeckDirector(builder DeckBuilder) *DeckDirector {
+	return &DeckDirector{builder: builder}
+}
+
+// Builds a GameCard from every input line and returns the built GameCardDeck. Returns a
+// ParseError with the line number of the first card that can't be built
+func (d *DeckDirector) Construct(input *[]string) (*GameCardDeck, error) {
+	// Iterate through input and build a card
+	for i, inputStr := range *input {
+		if err := d.builder.BuildCard(inputStr); err != nil {
+			return nil, utility.AtLine(err, i+1)
+		}
+	}
+	// Finished calling BuildCard() from input - get build GameCardDeck
+	return d.builder.GetCollection(), nil
+}
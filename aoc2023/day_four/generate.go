@@ -0,0 +1,146 @@
+package day_four
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+/*
+Settings for generating a synthetic "Card N: ... | ..." deck. Numbers are drawn from
+1..Pool. MatchWeights sets the match count distribution: index k is the relative weight
+of a card matching k numbers, ex. []int{80, 15, 5} for mostly losing cards. Without
+weights, scratched numbers are drawn from the pool like a real card, so matches follow
+Winning, Scratched, and Pool. CapMatches caps each card's matches at the cards left
+after it, so the deck never wins cards past the final card - like the real input. The
+same options and Seed always generate the same deck.
+*/
+type GeneratorOptions struct {
+	Seed         int64
+	Cards        int
+	Pool         int
+	Winning      int
+	Scratched    int
+	MatchWeights []int
+	CapMatches   bool
+}
+
+// Generator settings shaped like the real day 4 cards. Card copies grow exponentially
+// once cards average more than one match (the real input's match counts overflow part 2
+// when shuffled), so the default MatchWeights average 0.42 matches to keep part 2
+// linear in the card count, even at 10^5+ cards
+func DefaultGeneratorOptions() GeneratorOptions {
+	return GeneratorOptions{Seed: 1, Cards: 200, Pool: 99, Winning: 10, Scratched: 25,
+		MatchWeights: []int{70, 20, 8, 2}, CapMatches: true}
+}
+
+// Checks the options can generate a deck. Returns an error describing the first problem
+func (o GeneratorOptions) validate() error {
+	if o.Cards < 1 || o.Winning < 1 || o.Scratched < 1 {
+		return fmt.Errorf("cards, winning, and scratched counts should be at least 1")
+	}
+	if o.Pool < o.Winning+o.Scratched {
+		// a card may have no matches, so the pool needs room for disjoint numbers
+		return fmt.Errorf("pool of %d numbers is too small for %d winning and %d scratched",
+			o.Pool, o.Winning, o.Scratched)
+	}
+	if len(o.MatchWeights) > 0 {
+		total := 0
+		for k, weight := range o.MatchWeights {
+			if weight < 0 {
+				return fmt.Errorf("match weight %d for %d matches should be >= 0", weight, k)
+			}
+			if weight > 0 && k > min(o.Winning, o.Scratched) {
+				return fmt.Errorf("%d matches isn't possible with %d winning and %d scratched",
+					k, o.Winning, o.Scratched)
+			}
+			total += weight
+		}
+		if total == 0 {
+			return fmt.Errorf("match weights %v should have a weight > 0", o.MatchWeights)
+		}
+	}
+	return nil
+}
+
+// Generates a deck as input lines, ex. "Card   1: 41 48 83 86 17 | 83 86  6 31 17"
+func GenerateDeck(opts GeneratorOptions) ([]string, error) {
+	var sb strings.Builder
+	if err := WriteGeneratedDeck(&sb, opts); err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n"), nil
+}
+
+// Writes a generated deck to writer, one card per line. With CapMatches, match counts
+// are capped at the cards left after each card. Returns an error for invalid options.
+func WriteGeneratedDeck(writer io.Writer, opts GeneratorOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	random := rand.New(rand.NewSource(opts.Seed))
+	idWidth := len(strconv.Itoa(opts.Cards))
+	numWidth := len(strconv.Itoa(opts.Pool))
+
+	buffered := bufio.NewWriter(writer)
+	for id := 1; id <= opts.Cards; id++ {
+		// shuffle the pool - winning numbers come off the front, non-matching scratched
+		// numbers off the back so they can't collide with winning numbers
+		pool := random.Perm(opts.Pool)
+		winning := pool[:opts.Winning]
+		matches := opts.generateMatchCount(random)
+		if opts.CapMatches {
+			matches = min(matches, opts.Cards-id)
+		}
+		scratched := make([]int, 0, opts.Scratched)
+		scratched = append(scratched, winning[:matches]...)
+		scratched = append(scratched, pool[opts.Pool-(opts.Scratched-matches):]...)
+		random.Shuffle(len(scratched), func(i, j int) {
+			scratched[i], scratched[j] = scratched[j], scratched[i]
+		})
+
+		fmt.Fprintf(buffered, "Card %*d: %s | %s\n", idWidth, id,
+			formatNumbers(winning, numWidth), formatNumbers(scratched, numWidth))
+	}
+	return buffered.Flush()
+}
+
+// Picks how many numbers a card matches: from MatchWeights when set, otherwise by
+// drawing scratched numbers from the pool and counting the winning ones
+func (o GeneratorOptions) generateMatchCount(random *rand.Rand) int {
+	if len(o.MatchWeights) == 0 {
+		// winning numbers are 0..Winning-1 of the drawn permutation
+		matches := 0
+		for _, num := range random.Perm(o.Pool)[:o.Scratched] {
+			if num < o.Winning {
+				matches += 1
+			}
+		}
+		return matches
+	}
+
+	total := 0
+	for _, weight := range o.MatchWeights {
+		total += weight
+	}
+	pick := random.Intn(total)
+	for k, weight := range o.MatchWeights {
+		if pick < weight {
+			return k
+		}
+		pick -= weight
+	}
+	return 0
+}
+
+// Formats pool indexes as right aligned card numbers, ex. [0 40] -> " 1 41"
+func formatNumbers(poolIndexes []int, width int) string {
+	numStrings := make([]string, len(poolIndexes))
+	for i, poolIndex := range poolIndexes {
+		numStrings[i] = fmt.Sprintf("%*d", width, poolIndex+1)
+	}
+	return strings.Join(numStrings, " ")
+}
@@ -0,0 +1,119 @@
+package day_four
+
+import (
+	"slices"
+	"testing"
+)
+
+// Builds a generated deck with the text builder, failing the test for bad options
+func generateDeck(tb testing.TB, opts GeneratorOptions) *GameCardDeck {
+	input, err := GenerateDeck(opts)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	deck, err := ConstructGameCardDeck(&DeckBuilderConcrete{winBehaviorType: "points"}, &input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return deck
+}
+
+// Generated decks are deterministic by seed and follow the match weights
+func TestGenerateDeck(t *testing.T) {
+	opts := DefaultGeneratorOptions()
+	first, err := GenerateDeck(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := GenerateDeck(opts)
+	if !slices.Equal(first, second) {
+		t.Error("same seed generated different decks")
+	}
+	opts.Seed = 2
+	if other, _ := GenerateDeck(opts); slices.Equal(first, other) {
+		t.Error("different seeds generated the same deck")
+	}
+
+	// only 0 or 2 matches
+	opts.MatchWeights = []int{1, 0, 1}
+	for _, gameCard := range *generateDeck(t, opts).cards {
+		if matchCount := gameCard.matchCount(); matchCount != 0 && matchCount != 2 &&
+			gameCard.cardId < opts.Cards-1 {
+			t.Errorf("card %d has %d matches, want 0 or 2", gameCard.cardId, matchCount)
+		}
+	}
+
+	// every card matches 2, except the last two - capped so they don't win past the
+	// final card. Wins past the final card are recorded on the deck that was scored
+	opts.Cards = 30
+	opts.MatchWeights = []int{0, 0, 1}
+	deck := generateDeck(t, opts)
+	for _, gameCard := range *deck.cards {
+		if want := min(2, opts.Cards-gameCard.cardId); gameCard.matchCount() != want {
+			t.Errorf("card %d has %d matches, want %d", gameCard.cardId, gameCard.matchCount(),
+				want)
+		}
+	}
+	scored := rebuildDeck(t, deck, "cards")
+	countCards(scored)
+	if err := scored.OverflowErr(); err != nil {
+		t.Error(err)
+	}
+
+	// without the cap, the last two cards win cards 31 and 32
+	opts.CapMatches = false
+	scored = rebuildDeck(t, generateDeck(t, opts), "cards")
+	countCards(scored)
+	if missing := scored.MissingWins(); !slices.Equal(missing, []int{31, 32}) {
+		t.Errorf("uncapped deck wins past the final card = %v, want [31 32]", missing)
+	}
+	opts.CapMatches = true
+
+	opts.MatchWeights = []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	if _, err := GenerateDeck(opts); err == nil {
+		t.Error("11 matches with 10 winning numbers should be an error")
+	}
+}
+
+// The memoized and recursive card copy behaviors should agree on a 10^5 card deck.
+// -short skips it.
+func TestCardCopyWinBehaviorStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping 10^5 card deck in -short mode")
+	}
+	opts := DefaultGeneratorOptions()
+	opts.Cards = 100000
+	deck := generateDeck(t, opts)
+	memo := countCards(rebuildDeck(t, deck, "cards"))
+	recursive := countCards(rebuildDeck(t, deck, "cards-recursive"))
+	if memo != recursive {
+		t.Errorf("memo = %d, recursive = %d", memo, recursive)
+	}
+}
+
+// Compares the DeckBuilders on a generated 10^5 card deck, ex.
+// go test ./day_four -run=^$ -bench=DeckBuilders -benchmem
+func BenchmarkDeckBuilders(b *testing.B) {
+	opts := DefaultGeneratorOptions()
+	opts.Cards = 100000
+	input, err := GenerateDeck(opts)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, name := range []string{"text", "ints"} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				builder, err := deckBuilders[name]("cards")
+				if err != nil {
+					b.Fatal(err)
+				}
+				deck, err := NewDeckDirector(builder).Construct(&input)
+				if err != nil {
+					b.Fatal(err)
+				}
+				countCards(deck)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package day_four
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+A single GameCard in a JSON deck. Decks are JSON Lines - one card object per input line,
+ex. {"id": 1, "winning": [41, 48, 83, 86, 17], "numbers": [83, 86, 6, 31, 17, 9, 48, 53]}
+*/
+type jsonCard struct {
+	Id      *int  `json:"id"`
+	Winning []int `json:"winning"`
+	Numbers []int `json:"numbers"`
+}
+
+/*
+DeckBuilder for JSON decks: each input line is a jsonCard object. Numbers are already
+integers, so cards keep them pre-parsed like IntDeckBuilder. Reuses DeckBuilderConcrete
+for the deck and WinBehavior bookkeeping.
+*/
+type JsonDeckBuilder struct {
+	DeckBuilderConcrete
+}
+
+// Constructor creates a JsonDeckBuilder for a WinBehavior type. Returns an error if the
+// WinBehavior or its parameters aren't supported.
+func NewJsonDeckBuilder(winBehaviorType string) (*JsonDeckBuilder, error) {
+	builder, err := NewDeckBuilder(winBehaviorType)
+	if err != nil {
+		return nil, err
+	}
+	return &JsonDeckBuilder{DeckBuilderConcrete: *builder}, nil
+}
+
+// Decodes a JSON card object and adds the GameCard to the deck being built. Returns a
+// ParseError (without a line number) for invalid JSON, unknown fields, or a missing id
+func (b *JsonDeckBuilder) BuildCard(cardInputStr string) error {
+	decoder := json.NewDecoder(strings.NewReader(cardInputStr))
+	decoder.DisallowUnknownFields()
+	var card jsonCard
+	if err := decoder.Decode(&card); err != nil {
+		// point at the bad character when the decoder knows where it is
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return utility.NewParseError(0, int(syntaxErr.Offset), "invalid JSON card", err)
+		}
+		return utility.NewParseError(0, 0, "invalid JSON card", err)
+	}
+	// one card per line - anything after the object is a mistake, even invalid JSON
+	restOffset := int(decoder.InputOffset())
+	if _, err := decoder.Token(); err != io.EOF {
+		rest := cardInputStr[restOffset:]
+		column := restOffset + len(rest) - len(strings.TrimLeft(rest, " \t\r")) + 1
+		return utility.NewParseError(0, column, "expected one JSON card object per line", err)
+	}
+	if card.Id == nil {
+		return utility.NewParseError(0, 0, "missing card \"id\"", nil)
+	}
+
+	winMap := make(map[int]bool, len(card.Winning))
+	for _, num := range card.Winning {
+		winMap[num] = true
+	}
+	numbers := card.Numbers
+	if numbers == nil {
+		numbers = make([]int, 0)
+	}
+	gameCard := GameCard{cardId: *card.Id, winMap: &winMap, numbers: &numbers}
+	// duplicate ids point at the id key
+	return b.addCard(gameCard, idKeyColumn(cardInputStr))
+}
+
+// matches the "id" key of a JSON card, ex. "id": or "ID":
+var idKeyReg = regexp.MustCompile(`(?i)"id"\s*:`)
+
+// Finds the 1-based column of the id key, matched case insensitively like the decoder
+// matches keys. Returns 0 if it can't be found, ex. for an escaped key
+func idKeyColumn(cardInputStr string) int {
+	indices := idKeyReg.FindStringIndex(cardInputStr)
+	if indices == nil {
+		return 0
+	}
+	return indices[0] + 1
+}
+
+// Reports whether input looks like a JSON deck: its first non-blank line is an object
+func isJsonDeck(input *[]string) bool {
+	for _, inputStr := range *input {
+		if trimmed := strings.TrimSpace(inputStr); len(trimmed) > 0 {
+			return strings.HasPrefix(trimmed, "{")
+		}
+	}
+	return false
+}
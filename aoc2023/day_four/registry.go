@@ -0,0 +1,153 @@
+package day_four
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Creates the WinBehavior for a single GameCard in a deck
+type CardBehaviorFunc func(gameCardId int) WinBehavior
+
+/*
+A registered WinBehavior implementation: its name, a description, default parameter
+values, and a constructor. New is called once per deck (so behaviors can share state
+across the deck, like card copy totals) and returns the per-card constructor.
+*/
+type WinBehaviorSpec struct {
+	Name        string
+	Description string
+	Params      map[string]string
+	New         func(deck *GameCardDeck, params map[string]string) (CardBehaviorFunc, error)
+}
+
+// central registry of WinBehaviors keyed by name
+var winBehaviorRegistry = make(map[string]WinBehaviorSpec)
+
+// Adds a WinBehavior to the registry. Registering the same name twice panics.
+func RegisterWinBehavior(spec WinBehaviorSpec) {
+	if _, found := winBehaviorRegistry[spec.Name]; found {
+		panic("WinBehavior " + spec.Name + " already registered")
+	}
+	winBehaviorRegistry[spec.Name] = spec
+}
+
+// Returns all registered WinBehaviors ordered by name
+func WinBehaviors() []WinBehaviorSpec {
+	specs := make([]WinBehaviorSpec, 0, len(winBehaviorRegistry))
+	for _, spec := range winBehaviorRegistry {
+		specs = append(specs, spec)
+	}
+	slices.SortFunc(specs, func(a, b WinBehaviorSpec) int { return strings.Compare(a.Name, b.Name) })
+	return specs
+}
+
+// Creates the per-card WinBehavior constructor for a deck from a behavior string: a
+// registered name with optional parameters, ex. "points", "points:base=3", or
+// "cards:cap=2". Returns an error for unknown behaviors and parameters.
+func NewCardBehavior(deck *GameCardDeck, behaviorStr string) (CardBehaviorFunc, error) {
+	name, paramsStr, _ := strings.Cut(strings.TrimSpace(behaviorStr), ":")
+	spec, found := winBehaviorRegistry[name]
+	if !found {
+		names := make([]string, 0, len(winBehaviorRegistry))
+		for _, spec := range WinBehaviors() {
+			names = append(names, spec.Name)
+		}
+		return nil, fmt.Errorf("WinBehavior %q not supported: use one of %s", name,
+			strings.Join(names, ", "))
+	}
+
+	// start from the defaults, then apply the passed parameters
+	params := make(map[string]string, len(spec.Params))
+	for key, value := range spec.Params {
+		params[key] = value
+	}
+	for _, param := range strings.Split(paramsStr, ",") {
+		if len(strings.TrimSpace(param)) == 0 {
+			continue
+		}
+		key, value, ok := strings.Cut(param, "=")
+		key = strings.TrimSpace(key)
+		if _, known := spec.Params[key]; !ok || !known {
+			return nil, fmt.Errorf("WinBehavior %s parameter %q not supported: use key=value with keys %v",
+				name, param, paramKeys(spec.Params))
+		}
+		params[key] = strings.TrimSpace(value)
+	}
+	return spec.New(deck, params)
+}
+
+// Sorted parameter names for error messages
+func paramKeys(params map[string]string) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Reads an integer parameter that has to be at least min
+func intParam(params map[string]string, key string, min int) (int, error) {
+	value, err := strconv.Atoi(params[key])
+	if err != nil || value < min {
+		return 0, fmt.Errorf("parameter %s=%q should be a number >= %d", key, params[key], min)
+	}
+	return value, nil
+}
+
+// Built in WinBehaviors
+func init() {
+	RegisterWinBehavior(WinBehaviorSpec{
+		Name:        "points",
+		Description: "1 point for the first match, multiplied by base for each match after",
+		Params:      map[string]string{"base": "2"},
+		New: func(deck *GameCardDeck, params map[string]string) (CardBehaviorFunc, error) {
+			base, err := intParam(params, "base", 1)
+			if err != nil {
+				return nil, err
+			}
+			return func(int) WinBehavior { return &PointsWinBehavior{base: base} }, nil
+		},
+	})
+	RegisterWinBehavior(WinBehaviorSpec{
+		Name:        "linear",
+		Description: "per points for each match",
+		Params:      map[string]string{"per": "1"},
+		New: func(deck *GameCardDeck, params map[string]string) (CardBehaviorFunc, error) {
+			per, err := intParam(params, "per", 0)
+			if err != nil {
+				return nil, err
+			}
+			return func(int) WinBehavior { return &LinearWinBehavior{per: per} }, nil
+		},
+	})
+	RegisterWinBehavior(WinBehaviorSpec{
+		Name: "cards",
+		Description: "count this card plus every card copy won from it, winning at most cap " +
+			"copies per card (0 for no cap)",
+		Params: map[string]string{"cap": "0"},
+		New: func(deck *GameCardDeck, params map[string]string) (CardBehaviorFunc, error) {
+			maxWins, err := intParam(params, "cap", 0)
+			if err != nil {
+				return nil, err
+			}
+			// every card in the deck shares the copy totals table
+			totals := &cardCopyTotals{cardDeck: deck, maxWins: maxWins}
+			return func(gameCardId int) WinBehavior {
+				return &MemoCardCopyWinBehavior{gameCardId: gameCardId, totals: totals}
+			}, nil
+		},
+	})
+	RegisterWinBehavior(WinBehaviorSpec{
+		Name:        "cards-recursive",
+		Description: "same as cards with no cap, recursing into every won copy (slow)",
+		Params:      map[string]string{},
+		New: func(deck *GameCardDeck, params map[string]string) (CardBehaviorFunc, error) {
+			return func(gameCardId int) WinBehavior {
+				return &CardCopyWinBehavior{gameCardId: gameCardId, cardDeck: deck}
+			}, nil
+		},
+	})
+}
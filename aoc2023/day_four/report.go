@@ -0,0 +1,153 @@
+package day_four
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+Scoring details for a single GameCard: its winning numbers, which scratched numbers
+matched, points under PointsWinBehavior (base 2), and how many copies of the card are
+held once every card copy has been won
+*/
+type CardReport struct {
+	CardId         int
+	WinningNumbers []int
+	MatchedNumbers []int
+	MatchCount     int
+	Points         int
+	Copies         int
+}
+
+// Builds a CardReport for every GameCard in the deck, ordered by card id. Copies follow
+// the card copy rules: every copy of a card wins one copy of each of the next matchCount
+// cards. Wins past the final card are left to the deck's overflow policy.
+func ReportDeck(deck *GameCardDeck) []CardReport {
+	cards := slices.Clone(*deck.cards)
+	slices.SortFunc(cards, func(a, b GameCard) int { return a.cardId - b.cardId })
+
+	// every card starts with the original - copies only flow to higher card ids, so one
+	// pass in id order is enough
+	copies := make(map[int]int, len(cards))
+	for _, gameCard := range cards {
+		copies[gameCard.cardId] = 1
+	}
+	points := PointsWinBehavior{base: 2}
+	reports := make([]CardReport, len(cards))
+	for i, gameCard := range cards {
+		matchCount := gameCard.matchCount()
+		for offset := 1; offset <= matchCount; offset++ {
+			wonId := gameCard.cardId + offset
+			if _, found := deck.wonCard(wonId); found {
+				copies[wonId] += copies[gameCard.cardId]
+			}
+		}
+		reports[i] = CardReport{
+			CardId:         gameCard.cardId,
+			WinningNumbers: gameCard.winningNumbers(),
+			MatchedNumbers: gameCard.matchedNumbers(),
+			MatchCount:     matchCount,
+			Points:         points.Win(matchCount),
+			Copies:         copies[gameCard.cardId],
+		}
+	}
+	return reports
+}
+
+// Winning numbers in ascending order
+func (c *GameCard) winningNumbers() []int {
+	numbers := make([]int, 0, len(*c.winMap))
+	for num := range *c.winMap {
+		numbers = append(numbers, num)
+	}
+	slices.Sort(numbers)
+	return numbers
+}
+
+// Scratched numbers that match a winning number, in the order they appear on the card
+func (c *GameCard) matchedNumbers() []int {
+	matched := make([]int, 0)
+	for _, num := range c.scratchedNumbers() {
+		if (*c.winMap)[num] {
+			matched = append(matched, num)
+		}
+	}
+	return matched
+}
+
+// Day 4 exporter: writes a per-card scoring report as an aligned text table
+// ("table") or CSV ("csv") for input parsed with the default settings
+func ExportDayFour(writer io.Writer, input *[]string, opts utility.ExportOptions) error {
+	puzzle, err := ParseDayFour(input)
+	if err != nil {
+		return err
+	}
+	return writeDeckReport(writer, puzzle.(*scratchcards).deck, opts)
+}
+
+// Writes the per-card scoring report for a parsed deck in opts.Format. Returns an error
+// for unsupported formats, or for wins past the final card unless the deck clamps them
+func writeDeckReport(writer io.Writer, deck *GameCardDeck, opts utility.ExportOptions) error {
+	reports := ReportDeck(deck)
+
+	var err error
+	switch strings.ToLower(opts.Format) {
+	case "table":
+		err = writeReportTable(writer, reports)
+	case "csv":
+		err = writeReportCsv(writer, reports)
+	default:
+		return fmt.Errorf("export format %q not supported: use table or csv", opts.Format)
+	}
+	if err != nil {
+		return err
+	}
+	return deck.OverflowErr()
+}
+
+// Writes the reports as an aligned table with a totals row
+func writeReportTable(writer io.Writer, reports []CardReport) error {
+	table := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "CARD\tWINNING\tMATCHED\tMATCHES\tPOINTS\tCOPIES")
+	totalPoints, totalCopies := 0, 0
+	for _, report := range reports {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%d\t%d\t%d\n", report.CardId,
+			joinNumbers(report.WinningNumbers, " "), joinNumbers(report.MatchedNumbers, " "),
+			report.MatchCount, report.Points, report.Copies)
+		totalPoints += report.Points
+		totalCopies += report.Copies
+	}
+	fmt.Fprintf(table, "TOTAL\t\t\t\t%d\t%d\n", totalPoints, totalCopies)
+	return table.Flush()
+}
+
+// Writes one CSV row per card. Number lists are space separated within their column.
+func writeReportCsv(writer io.Writer, reports []CardReport) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"card", "winning_numbers", "matched_numbers", "match_count",
+		"points", "copies"})
+	for _, report := range reports {
+		csvWriter.Write([]string{strconv.Itoa(report.CardId),
+			joinNumbers(report.WinningNumbers, " "), joinNumbers(report.MatchedNumbers, " "),
+			strconv.Itoa(report.MatchCount), strconv.Itoa(report.Points),
+			strconv.Itoa(report.Copies)})
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// Joins numbers with a separator, ex. [41 48] -> "41 48"
+func joinNumbers(numbers []int, sep string) string {
+	numStrings := make([]string, len(numbers))
+	for i, num := range numbers {
+		numStrings[i] = strconv.Itoa(num)
+	}
+	return strings.Join(numStrings, sep)
+}
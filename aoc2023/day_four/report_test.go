@@ -0,0 +1,34 @@
+package day_four
+
+import (
+	"slices"
+	"testing"
+)
+
+// The report's point and copy totals should match the part 1 and part 2 scores
+func TestReportDeck(t *testing.T) {
+	for _, filepath := range []string{"../data/day_four_ex.txt", "../data/day_four_input.txt"} {
+		deck := loadDeck(t, filepath)
+		reports := ReportDeck(deck)
+		totalPoints, totalCopies := 0, 0
+		for _, report := range reports {
+			totalPoints += report.Points
+			totalCopies += report.Copies
+		}
+		if points := countCards(deck); totalPoints != points {
+			t.Errorf("%s: report points = %d, part 1 = %d", filepath, totalPoints, points)
+		}
+		if copies := countCards(rebuildDeck(t, deck, "cards")); totalCopies != copies {
+			t.Errorf("%s: report copies = %d, part 2 = %d", filepath, totalCopies, copies)
+		}
+	}
+
+	// example card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
+	first := ReportDeck(loadDeck(t, "../data/day_four_ex.txt"))[0]
+	if want := []int{83, 86, 17, 48}; !slices.Equal(first.MatchedNumbers, want) {
+		t.Errorf("card 1 matched = %v, want %v", first.MatchedNumbers, want)
+	}
+	if first.MatchCount != 4 || first.Points != 8 || first.Copies != 1 {
+		t.Errorf("card 1 = %+v, want 4 matches, 8 points, 1 copy", first)
+	}
+}
@@ -2,6 +2,8 @@ package day_four
 
 import (
 	"math"
+	"slices"
+	"sync"
 )
 
 /*
@@ -38,6 +40,18 @@ func (w *PointsWinBehavior) Win(matchCount int) int {
 	return int(points)
 }
 
+/*
+WinBehavior concretion. Scores a fixed number of points (per) for each match
+*/
+type LinearWinBehavior struct {
+	per int
+}
+
+// Implements WinBehavior Win() interface: per points for every match
+func (w *LinearWinBehavior) Win(matchCount int) int {
+	return w.per * matchCount
+}
+
 /*
 WinBehavior concretion. This data structure supports scoring based on number of cards
 win. This implementation relies on awareness of a GameCardDeck collection (to select
@@ -57,9 +71,13 @@ func (w *CardCopyWinBehavior) Win(matchCount int) int {
 	// Grab won cards from deck
 	wonCards := make([]GameCard, 0)
 	for offset := 1; offset <= matchCount; offset++ {
-		// fetch won card and add it slice of cards
+		// fetch won card and add it slice of cards - cards past the end of the deck are
+		// left to the deck's overflow policy
 		offsetId := w.gameCardId + offset
-		wonCard := w.cardDeck.Get(offsetId)
+		wonCard, found := w.cardDeck.wonCard(offsetId)
+		if !found {
+			continue
+		}
 		wonCards = append(wonCards, *wonCard)
 	}
 	// curScore := len(wonCards) // we won 0 or more cards for this card - include in score
@@ -75,3 +93,75 @@ func (w *CardCopyWinBehavior) Win(matchCount int) int {
 	score := curScore + recursiveScore
 	return score
 }
+
+/*
+WinBehavior concretion. Scores the same as CardCopyWinBehavior (this card plus every
+card copy won from it, recursively), but looks won card totals up in a table shared by
+the whole deck instead of recursing into every won copy.
+*/
+type MemoCardCopyWinBehavior struct {
+	gameCardId int
+	totals     *cardCopyTotals
+}
+
+/*
+Calculates score as 1 (this card) plus the total cards produced by each won card. Won
+cards are selected in an offset range from current game card id, same as
+CardCopyWinBehavior.
+*/
+func (w *MemoCardCopyWinBehavior) Win(matchCount int) int {
+	score := 1 // we have this card - count it torwards the score
+	for offset := 1; offset <= w.totals.wins(matchCount); offset++ {
+		score += w.totals.Total(w.gameCardId + offset)
+	}
+	return score
+}
+
+/*
+Table of how many cards each GameCard in a deck produces: the card itself plus
+everything won from it, recursively. Computed once, on first use, in a single pass from
+the highest card id to the lowest - a card only wins cards with higher ids, so their
+totals are already known. A card wins at most maxWins copies (0 means no cap).
+*/
+type cardCopyTotals struct {
+	once     sync.Once
+	cardDeck *GameCardDeck
+	maxWins  int
+	totals   map[int]int // card id: total cards produced
+}
+
+// Number of card copies won for a match count, after applying the cap
+func (t *cardCopyTotals) wins(matchCount int) int {
+	if t.maxWins > 0 {
+		return min(matchCount, t.maxWins)
+	}
+	return matchCount
+}
+
+// Returns the total cards produced by the card with id. Cards missing from the deck
+// produce 0 - ids past the final card are left to the deck's overflow policy.
+func (t *cardCopyTotals) Total(id int) int {
+	// the deck is still being built when behaviors are created - wait for the first Win()
+	t.once.Do(t.compute)
+	if _, found := t.cardDeck.wonCard(id); !found {
+		return 0
+	}
+	return t.totals[id]
+}
+
+// Fills in totals from the highest card id to the lowest
+func (t *cardCopyTotals) compute() {
+	cards := slices.Clone(*t.cardDeck.cards)
+	slices.SortFunc(cards, func(a, b GameCard) int { return b.cardId - a.cardId })
+	t.totals = make(map[int]int, len(cards))
+	for _, gameCard := range cards {
+		total := 1
+		for offset := 1; offset <= t.wins(gameCard.matchCount()); offset++ {
+			wonId := gameCard.cardId + offset
+			if _, found := t.cardDeck.wonCard(wonId); found {
+				total += t.totals[wonId]
+			}
+		}
+		t.totals[gameCard.cardId] = total
+	}
+}
@@ -0,0 +1,140 @@
+package day_four
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Reads a day four input file from data/ and parses it into a GameCardDeck
+func loadDeck(tb testing.TB, filepath string) *GameCardDeck {
+	content, err := os.ReadFile(filepath)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	input := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	deck, err := ConstructGameCardDeck(&DeckBuilderConcrete{winBehaviorType: "points"}, &input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return deck
+}
+
+// Rebuilds a deck with a WinBehavior type, failing the test for unsupported types
+func rebuildDeck(tb testing.TB, deck *GameCardDeck, winBehaviorType string) *GameCardDeck {
+	rebuilt, err := RebuildWithWinBehavior(deck, winBehaviorType)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return rebuilt
+}
+
+// Sums the cards produced by every card in the deck, like part 2 does
+func countCards(deck *GameCardDeck) int {
+	total := 0
+	for _, gameCard := range *deck.cards {
+		total += gameCard.Win()
+	}
+	return total
+}
+
+// The memoized and recursive card copy behaviors should agree on every card. The
+// recursive behavior takes several seconds on the real input - -short skips it.
+func TestMemoCardCopyWinBehavior(t *testing.T) {
+	filepaths := []string{"../data/day_four_ex.txt", "../data/day_four_input.txt"}
+	if testing.Short() {
+		filepaths = filepaths[:1]
+	}
+	for _, filepath := range filepaths {
+		deck := loadDeck(t, filepath)
+		memoDeck := rebuildDeck(t, deck, "cards")
+		recursiveDeck := rebuildDeck(t, deck, "cards-recursive")
+		for i := range *deck.cards {
+			memoCard := (*memoDeck.cards)[i]
+			recursiveCard := (*recursiveDeck.cards)[i]
+			if memo, recursive := memoCard.Win(), recursiveCard.Win(); memo != recursive {
+				t.Errorf("%s card %d: memo = %d, recursive = %d", filepath, memoCard.Id(), memo,
+					recursive)
+			}
+		}
+	}
+}
+
+// Compares the memoized and recursive card copy behaviors, ex.
+// go test ./day_four -run=^$ -bench=CardCopy -benchmem
+func BenchmarkCardCopyWinBehavior(b *testing.B) {
+	inputs := map[string]string{
+		"example": "../data/day_four_ex.txt",
+		"real":    "../data/day_four_input.txt",
+	}
+	for _, inputName := range []string{"example", "real"} {
+		deck := loadDeck(b, inputs[inputName])
+		for _, winBehaviorType := range []string{"cards", "cards-recursive"} {
+			b.Run(inputName+"/"+winBehaviorType, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					// rebuild every run so memoized totals aren't reused between runs
+					rebuilt, err := RebuildWithWinBehavior(deck, winBehaviorType)
+					if err != nil {
+						b.Fatal(err)
+					}
+					countCards(rebuilt)
+				}
+			})
+		}
+	}
+}
+
+// Cards are found by id in any order, and wins past the final card follow the overflow
+// policy: card 3 wins card 4, which isn't in the deck
+func TestCardCopyOverflowPolicy(t *testing.T) {
+	input := []string{"Card 1: 1 2 | 1 2", "Card 3: 5 | 5", "Card 2: 1 | 1"}
+	for _, winBehaviorType := range []string{"cards", "cards-recursive"} {
+		deck, err := ConstructGameCardDeck(&DeckBuilderConcrete{winBehaviorType: "points"}, &input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deck.SetOverflowPolicy(OverflowClamp)
+		clamped := rebuildDeck(t, deck, winBehaviorType)
+		// card 3: 1, card 2: 1 + 1 (card 3), card 1: 1 + 2 (card 2) + 1 (card 3)
+		if got := countCards(clamped); got != 7 {
+			t.Errorf("%s clamped cards = %d, want 7", winBehaviorType, got)
+		}
+		if err := clamped.OverflowErr(); err != nil {
+			t.Errorf("%s clamped deck error: %v", winBehaviorType, err)
+		}
+
+		deck.SetOverflowPolicy(OverflowReport)
+		reported := rebuildDeck(t, deck, winBehaviorType)
+		countCards(reported)
+		if err := reported.OverflowErr(); !errors.Is(err, ErrCardNotFound) {
+			t.Errorf("%s reported deck error = %v, want ErrCardNotFound", winBehaviorType, err)
+		}
+	}
+}
+
+// Won ids in a gap between card ids don't win a card and aren't overflow: card 1 wins
+// card 2, which isn't in the deck, but card 5 is
+func TestCardCopyGappedDeck(t *testing.T) {
+	input := []string{"Card 1: 1 | 1", "Card 3: 2 | 3", "Card 5: 4 | 4"}
+	for _, winBehaviorType := range []string{"cards", "cards-recursive"} {
+		deck, err := ConstructGameCardDeck(&DeckBuilderConcrete{winBehaviorType: "points"}, &input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scored := rebuildDeck(t, deck, winBehaviorType)
+		if got := countCards(scored); got != 3 {
+			t.Errorf("%s cards = %d, want 3", winBehaviorType, got)
+		}
+		if gaps := scored.GapWins(); !slices.Equal(gaps, []int{2}) {
+			t.Errorf("%s gap wins = %v, want [2]", winBehaviorType, gaps)
+		}
+		// card 5 wins card 6, past the final card
+		if err := scored.OverflowErr(); err == nil || !slices.Equal(scored.MissingWins(), []int{6}) {
+			t.Errorf("%s overflow = %v, want won cards [6]", winBehaviorType, err)
+		}
+	}
+}
@@ -9,67 +9,93 @@ import (
 	"github.com/dswelbor/adventofcode/aoc2023/utility"
 )
 
-func SolveDayOne(input *[]string, part int) {
+// Day 1 Solver - registered with the central Solver registry
+var dayOneSolver = utility.NewSolver(1, []int{1, 2}, ParseDayOne)
 
+func init() {
+	utility.RegisterSolver(dayOneSolver)
+}
+
+// Parsed Day 1 input: the calibration document lines
+type calibrationDocument struct {
+	lines *[]string
+}
+
+// Parses the Day 1 input into a Puzzle shared by both parts. Calibration lines are used as is
+func ParseDayOne(input *[]string) (utility.Puzzle, error) {
+	return &calibrationDocument{lines: input}, nil
+}
+
+// Puzzle.Solve() implementation: solves a part against the shared parsed input
+func (p *calibrationDocument) Solve(part int) (*utility.Result, error) {
 	if part == 1 {
-		solvePartOne(input)
+		return solvePartOne(p.lines)
 	} else if part == 2 {
-		solvePartTwo(input)
-	} else {
-		fmt.Println("Part: " + strconv.Itoa(part) + "Not supported")
+		return solvePartTwo(p.lines)
 	}
+	return nil, fmt.Errorf("part %d not supported", part)
+}
 
+// High level entry point: parses the input and solves a single part
+func SolveDayOne(input *[]string, part int) (*utility.Result, error) {
+	return dayOneSolver.Solve(input, part)
 }
 
-func solvePartOne(input *[]string) {
-	fmt.Println("--- Solving Day One - Part One! ---")
+func solvePartOne(input *[]string) (*utility.Result, error) {
 	// Get all the calibration partOneNumbers
 	partOneRegex := "\\d"
-	partOneNumbers := calibrationNumbers(input, partOneRegex, nil)
+	partOneNumbers, err := calibrationNumbers(input, partOneRegex, nil)
+	if err != nil {
+		return nil, err
+	}
 	// fmt.Println(*numbers)
 
 	// Sum all the calibration numbers
 	partOneSum := utility.SumNumbers(partOneNumbers)
 
-	// Print the sum
-	resultStr := "Calibration Number Sum: " + strconv.Itoa(partOneSum)
-	fmt.Println(resultStr)
+	// Return the sum
+	return utility.NewResult(1, 1, "Calibration Number Sum", partOneSum), nil
 }
 
-func solvePartTwo(input *[]string) {
-	fmt.Println("--- Solving Day One - Part Two! ---")
+func solvePartTwo(input *[]string) (*utility.Result, error) {
 	// Create map of int and string representations of numbers
 	digitsMapPtr := createWordIntMap()
 
 	// Get all the calibration partOneNumbers
 	partTwoRegex := "(\\d|one|two|three|four|five|six|seven|eight|nine)"
-	partTwoNumbers := calibrationNumbers(input, partTwoRegex, digitsMapPtr)
+	partTwoNumbers, err := calibrationNumbers(input, partTwoRegex, digitsMapPtr)
+	if err != nil {
+		return nil, err
+	}
 
 	// Sum all the calibration numbers
 	partTwoSum := utility.SumNumbers(partTwoNumbers)
 
-	// Print the sum
-	resultStr := "Calibration Number Sum: " + strconv.Itoa(partTwoSum)
-	fmt.Println(resultStr)
+	// Return the sum
+	return utility.NewResult(1, 2, "Calibration Number Sum", partTwoSum), nil
 }
 
 // takes a pointer to a slice of strings, parses callibration numbers, and returns a pointer to a list of callibration numbers
-func calibrationNumbers(input *[]string, regexPattern string, digitsMap *map[string]string) *[]int {
+// Returns a ParseError for the first line without a calibration number
+func calibrationNumbers(input *[]string, regexPattern string, digitsMap *map[string]string) (*[]int, error) {
 	// iterate through each line and add parsed calibrationNumber to slice
 	numbers := make([]int, 0)
 
-	for _, strElement := range *input {
-		num := calibrationNumber(strElement, regexPattern, digitsMap)
+	for i, strElement := range *input {
+		num, err := calibrationNumber(strElement, regexPattern, digitsMap)
+		if err != nil {
+			return nil, utility.AtLine(err, i+1)
+		}
 		numbers = append(numbers, num)
 	}
 
 	// return slice of parsed calibration numbers
-	return &numbers
+	return &numbers, nil
 
 }
 
 // Parse a calibration number from an input string line
-func calibrationNumber(inputStr string, regexPattern string, digitsMap *map[string]string) int {
+func calibrationNumber(inputStr string, regexPattern string, digitsMap *map[string]string) (int, error) {
 	// Grab all overlapping matches
 	// Note: regexp.FindAllString(string) does not support overlapping matches
 	// This is important since abconeightxyz should return matches: ["one", "eight"] with a shared 'e'
@@ -81,19 +107,23 @@ func calibrationNumber(inputStr string, regexPattern string, digitsMap *map[stri
 
 	// dereference matches
 	matches := *matchesPtr
-	// grab first and last numbers
+	// edge case - no numbers in the line
 	matchesLen := len(matches)
+	if matchesLen == 0 {
+		return 0, utility.NewParseError(0, 0, "no calibration numbers found in \""+inputStr+"\"", nil)
+	}
+	// grab first and last numbers
 	firstNum := matches[0]
 	lastNum := matches[matchesLen-1]
 
 	// combine the calibration number elements
 	combinedNum, err := strconv.Atoi(firstNum + lastNum)
 	if err != nil {
-		panic(err)
+		return 0, utility.NewParseError(0, 0, "problem combining calibration numbers", err)
 	}
 
 	// return the combined calibration number
-	return combinedNum
+	return combinedNum, nil
 }
 
 /*
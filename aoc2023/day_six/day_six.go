@@ -58,44 +58,68 @@ func (r *RaceRecord) Moves() *[]int {
 
 }
 
-// High level entry Point for Day 4 solution
-func SolveDaySix(input *[]string, part int) {
+// Day 6 Solver - registered with the central Solver registry
+var daySixSolver = utility.NewSolver(6, []int{1, 2}, ParseDaySix)
 
+func init() {
+	utility.RegisterSolver(daySixSolver)
+}
+
+// Parsed Day 6 input: RaceRecords as written and with fixed kerning
+type raceSheet struct {
+	records      *[]RaceRecord
+	fixedRecords *[]RaceRecord
+}
+
+// Parses the Day 6 input into a Puzzle shared by both parts. Both the per-race records
+// and the single record with fixed "kerning" (spaces removed) are parsed
+func ParseDaySix(input *[]string) (utility.Puzzle, error) {
+	records, err := parseRaceRecords(input, false)
+	if err != nil {
+		return nil, err
+	}
+	fixedRecords, err := parseRaceRecords(input, true)
+	if err != nil {
+		return nil, err
+	}
+	return &raceSheet{records: records, fixedRecords: fixedRecords}, nil
+}
+
+// Puzzle.Solve() implementation: solves a part against the shared parsed input
+func (p *raceSheet) Solve(part int) (*utility.Result, error) {
 	if part == 1 {
-		solvePartOne(input)
+		return solvePartOne(p.records), nil
 	} else if part == 2 {
-		solvePartTwo(input)
-	} else {
-		fmt.Println("Part: " + strconv.Itoa(part) + "Not supported")
+		return solvePartTwo(p.fixedRecords), nil
 	}
+	return nil, fmt.Errorf("part %d not supported", part)
+}
 
+// High level entry point: parses the input and solves a single part
+func SolveDaySix(input *[]string, part int) (*utility.Result, error) {
+	return daySixSolver.Solve(input, part)
 }
 
 // Entry point for day 6 part 1 solution
-func solvePartOne(input *[]string) {
-	fmt.Println("--- Solving Day Six - Part One! ---")
-
-	// Fetch Records
-	raceRecords := parseRaceRecords(input, false)
+func solvePartOne(raceRecords *[]RaceRecord) *utility.Result {
 	// Fetch winning move counts
 	moveCounts := listMoveCounts(raceRecords)
 	// Calculate margin for error - multiple all elements of moveCounts
 	errMargin := utility.MultipleNumbers(moveCounts)
-	fmt.Println("Margin for error (product of winning move counts): ", errMargin)
-
+	result := utility.NewResult(6, 1, "Margin for error (product of winning move counts)", errMargin)
+	result.AddDiagnostic("winning move counts: " + fmt.Sprint(*moveCounts))
+	return result
 }
 
 // Entry point for day 6 part 2 solution
-func solvePartTwo(input *[]string) {
-	fmt.Println("--- Solving Day Six - Part Two! ---")
-
-	// Fetch Records - fix "kerning" by replacing spaces in input
-	raceRecords := parseRaceRecords(input, true)
+func solvePartTwo(raceRecords *[]RaceRecord) *utility.Result {
+	// Records have fixed "kerning" - spaces in input were replaced
 	// Fetch winning move counts
 	moveCounts := listMoveCounts(raceRecords)
 	// Calculate margin for error - multiple all elements of moveCounts
 	errMargin := utility.MultipleNumbers(moveCounts)
-	fmt.Println("Margin for error (product of winning move counts) with fixed kerning: ", errMargin)
+	return utility.NewResult(6, 2,
+		"Margin for error (product of winning move counts) with fixed kerning", errMargin)
 }
 
 func listMoveCounts(raceRecords *[]RaceRecord) *[]int {
@@ -113,44 +137,80 @@ func listMoveCounts(raceRecords *[]RaceRecord) *[]int {
 	return &moveCounts
 }
 
-// Utility functions iterates through input and builds a list of RaceRecords
-func parseRaceRecords(input *[]string, replaceSpaces bool) *[]RaceRecord {
+// Utility functions iterates through input and builds a list of RaceRecords. Returns a
+// ParseError if the Time or Distance line is missing or they don't line up
+func parseRaceRecords(input *[]string, replaceSpaces bool) (*[]RaceRecord, error) {
 	// parse out the times and distance into parallel arrays
 	numReg := regexp.MustCompile("\\d+")
 	timeStrings := make([]string, 0)
 	distStrings := make([]string, 0)
-	for _, inputStr := range *input {
-		// Edge case - fix kerning by replacing all whitespace
+	// track 1-based line numbers and number columns for errors - 0 means the line
+	// wasn't found
+	timeLine, distLine := 0, 0
+	var timeColumns, distColumns []int
+	for i, inputStr := range *input {
+		// Edge case - fix kerning by replacing all whitespace. Numbers are still found
+		// in the original line so errors point at its columns
+		line := inputStr
 		if replaceSpaces {
 			spaceReg := regexp.MustCompile("\\s+")
 			inputStr = spaceReg.ReplaceAllString(inputStr, "")
 		}
 		if strings.HasPrefix(inputStr, "Time:") {
 			// Time input
-			timeStrings = numReg.FindAllString(inputStr, -1)
+			timeStrings, timeColumns = findNumbers(numReg, line, replaceSpaces)
+			timeLine = i + 1
 		} else if strings.HasPrefix(inputStr, "Distance:") {
 			// Distance input
-			distStrings = numReg.FindAllString(inputStr, -1)
-
+			distStrings, distColumns = findNumbers(numReg, line, replaceSpaces)
+			distLine = i + 1
 		} else {
-			fmt.Println("[DEBUG] Didn't recognize input: ", inputStr)
+			utility.Debugf("Didn't recognize input: %q", inputStr)
 		}
 	}
+	// Edge cases - missing lines or times and distances don't pair up
+	if timeLine == 0 {
+		return nil, utility.NewParseError(0, 0, "missing \"Time:\" line", nil)
+	}
+	if distLine == 0 {
+		return nil, utility.NewParseError(0, 0, "missing \"Distance:\" line", nil)
+	}
+	if len(timeStrings) != len(distStrings) {
+		return nil, utility.NewParseError(distLine, 0,
+			fmt.Sprintf("found %d distances for %d times", len(distStrings),
+				len(timeStrings)), nil)
+	}
+
 	// Translate parallel arrays into list of RaceRecords
 	raceCount := len(timeStrings)
 	raceRecords := make([]RaceRecord, raceCount)
 	for i := 0; i < raceCount; i++ {
 		// Parse number strings into int
-		time, tErr := strconv.Atoi(timeStrings[i])
-		dist, dErr := strconv.Atoi(distStrings[i])
-		if tErr != nil || dErr != nil {
-			fmt.Println("[ERROR] Encountered error when parsing time: ", timeStrings[i],
-				" or distance: ", timeStrings[i])
+		time, err := strconv.Atoi(timeStrings[i])
+		if err != nil {
+			return nil, utility.NewParseError(timeLine, timeColumns[i],
+				"problem parsing time", err)
+		}
+		dist, err := strconv.Atoi(distStrings[i])
+		if err != nil {
+			return nil, utility.NewParseError(distLine, distColumns[i],
+				"problem parsing distance", err)
 		}
 		// build RaceRecord struct and add to list
 		raceRecord := RaceRecord{totalTime: time, distance: dist}
 		raceRecords[i] = raceRecord
 	}
 	// ship it!
-	return &raceRecords
+	return &raceRecords, nil
+}
+
+// Helper function finds the number strings in an input line and their 1-based columns.
+// When joining, the numbers are read as a single number starting at the first one's
+// column, ex. "Time:      7  15   30" -> ["71530"] at column 12
+func findNumbers(numReg *regexp.Regexp, line string, join bool) ([]string, []int) {
+	numStrings, columns := utility.FindAllWithColumns(numReg, line)
+	if !join || len(numStrings) == 0 {
+		return numStrings, columns
+	}
+	return []string{strings.Join(numStrings, "")}, columns[:1]
 }
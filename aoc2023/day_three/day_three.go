@@ -8,20 +8,56 @@ import (
 	"github.com/dswelbor/adventofcode/aoc2023/utility"
 )
 
-func SolveDayThree(input *[]string, part int) {
+// Day 3 Solver - registered with the central Solver registry
+var dayThreeSolver = utility.NewSolver(3, []int{1, 2}, ParseDayThree)
 
+func init() {
+	utility.RegisterSolver(dayThreeSolver)
+}
+
+// Parsed Day 3 input: the mapped symbols and the list of Parts
+type engineSchematic struct {
+	symbols *SymbolCollection
+	parts   *[]Part
+}
+
+// Parses the Day 3 input into a Puzzle shared by both parts. Parts are validated
+// against the mapped symbols
+func ParseDayThree(input *[]string) (utility.Puzzle, error) {
+	// Map "symbols"
+	symbolPattern := "[\\@\\*\\&\\%\\#\\/\\+\\=\\$\\!\\^\\(\\)\\-\\_]"
+	symbols, err := mapSymbols(input, symbolPattern)
+	if err != nil {
+		return nil, err
+	}
+	// init validator - aware of symbol map
+	validator := StdPartValidator{symbols: symbols}
+	// Get list of Parts
+	partPattern := "\\d+"
+	parts, err := listParts(input, partPattern, &validator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &engineSchematic{symbols: symbols, parts: parts}, nil
+}
+
+// Puzzle.Solve() implementation: solves a part against the shared parsed input
+func (p *engineSchematic) Solve(part int) (*utility.Result, error) {
 	if part == 1 {
-		solvePartOne(input)
+		return solvePartOne(p.parts), nil
 	} else if part == 2 {
-		solvePartTwo(input)
-	} else {
-		fmt.Println("Part: " + strconv.Itoa(part) + "Not supported")
+		return solvePartTwo(p.symbols, p.parts), nil
 	}
+	return nil, fmt.Errorf("part %d not supported", part)
+}
 
+// High level entry point: parses the input and solves a single part
+func SolveDayThree(input *[]string, part int) (*utility.Result, error) {
+	return dayThreeSolver.Solve(input, part)
 }
 
-func solvePartOne(input *[]string) {
-	fmt.Println("--- Solving Day Three - Part One! ---")
+func solvePartOne(parts *[]Part) *utility.Result {
 	// doTheStuff(input)
 
 	// testList := make([]string, 10)
@@ -29,19 +65,12 @@ func solvePartOne(input *[]string) {
 	// testResult := testList[2]
 	// fmt.Println("[DEBUG] slice element not set test: ", testResult)
 
-	// Map "symbols"
-	symbolPattern := "[\\@\\*\\&\\%\\#\\/\\+\\=\\$\\!\\^\\(\\)\\-\\_]"
-	symbols := mapSymbols(input, symbolPattern)
-	// init validator - aware of symbol map
-	validator := StdPartValidator{symbols: symbols}
-	// Get list of Parts
-	partPattern := "\\d+"
-	parts := listParts(input, partPattern, &validator)
-
 	// Grab part numbers and sum
 	partNumbers := listPartNumbers(parts)
 	partsSum := utility.SumNumbers(partNumbers)
-	fmt.Println("Valid Part #'s Sum: ", partsSum)
+	result := utility.NewResult(3, 1, "Valid Part #'s Sum", partsSum)
+	result.AddDiagnostic("valid parts: " + strconv.Itoa(len(*partNumbers)) + " / " +
+		strconv.Itoa(len(*parts)))
 
 	// fmt.Println(symbols)
 	// fmt.Println(parts)
@@ -54,20 +83,10 @@ func solvePartOne(input *[]string) {
 	// Symbol, and Gear, though a Gear is a special kind of Symbol node.
 
 	// A Part should have a list of
+	return result
 }
 
-func solvePartTwo(input *[]string) {
-	fmt.Println("--- Solving Day Three - Part Two! ---")
-	// TODO: Implement me
-	// Map "symbols"
-	symbolPattern := "[\\@\\*\\&\\%\\#\\/\\+\\=\\$\\!\\^\\(\\)\\-\\_]"
-	symbols := mapSymbols(input, symbolPattern)
-	// init validator - aware of symbol map
-	// validator := StdPartValidator{symbols: symbols}
-	// Get list of Parts
-	partPattern := "\\d+"
-	parts := listParts(input, partPattern, nil)
-
+func solvePartTwo(symbols *SymbolCollection, parts *[]Part) *utility.Result {
 	// build reverse "gear" map - and build gears list
 	revGearParts := buildReverseGearMap(parts, symbols)
 	gears := listValidGears(revGearParts)
@@ -76,8 +95,10 @@ func solvePartTwo(input *[]string) {
 	gearRatios := listGearRatios(gears)
 	gearRatioSum := utility.SumNumbers(gearRatios)
 
-	// Print sum of gear ratios
-	fmt.Println("Sum of Gear Ratios: ", gearRatioSum)
+	// Return sum of gear ratios
+	result := utility.NewResult(3, 2, "Sum of Gear Ratios", gearRatioSum)
+	result.AddDiagnostic("gears: " + strconv.Itoa(len(*gears)))
+	return result
 }
 
 func findAdjacentCoords(rowIndex int, colIndices []int) *[][]int {
@@ -112,7 +133,8 @@ func findAdjacentCoords(rowIndex int, colIndices []int) *[][]int {
 	return &adjCoords
 }
 
-func listParts(input *[]string, regPattern string, validator *StdPartValidator) *[]Part {
+// Returns a ParseError if a part number can't be parsed
+func listParts(input *[]string, regPattern string, validator *StdPartValidator) (*[]Part, error) {
 	// init regex and part collection
 	reg := regexp.MustCompile(regPattern)
 	parts := make([]Part, 0)
@@ -125,7 +147,8 @@ func listParts(input *[]string, regPattern string, validator *StdPartValidator)
 			adjCoords := findAdjacentCoords(row, indices)
 			partNum, err := strconv.Atoi(inputStr[indices[0]:indices[1]])
 			if err != nil {
-				panic(err)
+				return nil, utility.NewParseError(row+1, indices[0]+1,
+					"problem parsing part number", err)
 			}
 			part := Part{
 				number:    partNum,
@@ -135,12 +158,17 @@ func listParts(input *[]string, regPattern string, validator *StdPartValidator)
 			parts = append(parts, part)
 		}
 	}
-	return &parts
+	return &parts, nil
 }
 
-func mapSymbols(input *[]string, regPattern string) *SymbolCollection {
+// Maps symbol coordinates in the schematic. Returns a ParseError for empty input or for
+// rows longer than the first row
+func mapSymbols(input *[]string, regPattern string) (*SymbolCollection, error) {
 	// init symbol collection
 	rowCount := len(*input)
+	if rowCount == 0 {
+		return nil, utility.NewParseError(0, 0, "empty schematic", nil)
+	}
 	colCount := len((*input)[0])
 	symbolCollection := CreateSymbolCollection(rowCount, colCount)
 	symbolCoords := *symbolCollection.symbolCoords
@@ -151,6 +179,11 @@ func mapSymbols(input *[]string, regPattern string) *SymbolCollection {
 
 	// Iterate through rows to map symbol coors
 	for row, inputStr := range *input {
+		// edge case - ragged rows don't fit the symbol collection
+		if len(inputStr) > colCount {
+			return nil, utility.NewParseError(row+1, colCount+1,
+				"row is longer than the first row ("+strconv.Itoa(colCount)+" columns)", nil)
+		}
 		symbolIndices := reg.FindAllStringIndex(inputStr, -1)
 		for _, indices := range symbolIndices {
 			// fmt.Print(indices)
@@ -160,11 +193,9 @@ func mapSymbols(input *[]string, regPattern string) *SymbolCollection {
 			// symbolCollection.symbolCoords[row][col] = symbol
 			symbolCoords[row][col] = symbol
 		}
-		fmt.Println(row)
-		fmt.Println(symbolIndices)
-
+		utility.Tracef("row %d symbol indices: %v", row, symbolIndices)
 	}
-	return symbolCollection
+	return symbolCollection, nil
 }
 
 // takes a part list and symbol map, filters valid parts based on adjacent coord checks,
@@ -205,8 +236,8 @@ func doTheStuff(input *[]string) {
 	// partReg := regexp.MustCompile("\\d+")
 	// partMatches := partReg.FindAllStringIndex(inputStr, -1)
 	// fmt.Println(partMatches)
-	fmt.Println("Count of Rows: ", len(*input))
-	fmt.Println("Count of Columns: ", len((*input)[0]))
+	utility.Debugf("Count of Rows: %d", len(*input))
+	utility.Debugf("Count of Columns: %d", len((*input)[0]))
 
 	// Grab "symbol chars"
 	runeMap := make(map[rune]bool)
@@ -216,14 +247,16 @@ func doTheStuff(input *[]string) {
 			runeMap[r] = true
 		}
 	}
-	fmt.Println(runeMap)
+	utility.Debugf("rune map: %v", runeMap)
 
-	// print runes
+	// log runes
+	symbolRunes := make([]rune, 0)
 	for r := range runeMap {
 		if r != '.' {
-			fmt.Printf("%c", r)
+			symbolRunes = append(symbolRunes, r)
 		}
 	}
+	utility.Debugf("symbol runes: %s", string(symbolRunes))
 }
 
 // Goes through a builds a
@@ -62,14 +62,14 @@ func (r rgbDiceRound) Info() *map[string]string {
 }
 
 type rgbDiceGame struct {
-	gameId    string
+	gameId    int
 	validator *utility.Validator
 	gamePower *utility.PowerBehavior
 	games     *[]utility.GameRound
 }
 
 func (g rgbDiceGame) Id() string {
-	return g.gameId
+	return strconv.Itoa(g.gameId)
 }
 
 // return id and game info as string=>string map
@@ -152,31 +152,48 @@ func (p stdPowerBehavior) Power(gameRounds *[]utility.GameRound) int {
 
 }
 
-func SolveDayTwo(input *[]string, part int) {
+// Day 2 Solver - registered with the central Solver registry
+var dayTwoSolver = utility.NewSolver(2, []int{1, 2}, ParseDayTwo)
 
+func init() {
+	utility.RegisterSolver(dayTwoSolver)
+}
+
+// Parsed Day 2 input: the list of recorded Games
+type gamesRecord struct {
+	games *[]utility.Game
+}
+
+// Parses the Day 2 input into a Puzzle shared by both parts
+func ParseDayTwo(input *[]string) (utility.Puzzle, error) {
+	gamesPtr, err := buildGamesList(input)
+	if err != nil {
+		return nil, err
+	}
+	return &gamesRecord{games: gamesPtr}, nil
+}
+
+// Puzzle.Solve() implementation: solves a part against the shared parsed input
+func (p *gamesRecord) Solve(part int) (*utility.Result, error) {
 	if part == 1 {
-		solvePartOne(input)
+		return solvePartOne(p.games), nil
 	} else if part == 2 {
-		solvePartTwo(input)
-	} else {
-		fmt.Println("Part: " + strconv.Itoa(part) + "Not supported")
+		return solvePartTwo(p.games), nil
 	}
-
+	return nil, fmt.Errorf("part %d not supported", part)
 }
 
-func solvePartOne(input *[]string) {
-	fmt.Println("--- Solving Day Two - Part One! ---")
-
-	// Build a list of Games
-	gamesPtr := buildGamesList(input)
+// High level entry point: parses the input and solves a single part
+func SolveDayTwo(input *[]string, part int) (*utility.Result, error) {
+	return dayTwoSolver.Solve(input, part)
+}
 
+func solvePartOne(gamesPtr *[]utility.Game) *utility.Result {
 	// Iterate through parsed games and add valid gameIds to list
 	validGameIds := make([]int, 0)
 	for _, gamePtr := range *gamesPtr {
-		gameId, err := strconv.Atoi(gamePtr.Id())
-		if err != nil {
-			panic(err)
-		}
+		// note: game ids are parsed to ints when the game is built, so this can't fail
+		gameId, _ := strconv.Atoi(gamePtr.Id())
 		gameStatus := *gamePtr.Info()
 		valid, err := strconv.ParseBool(gameStatus["valid"])
 		if err == nil && valid {
@@ -189,37 +206,34 @@ func solvePartOne(input *[]string) {
 	// Calc game id sum
 	idSum := utility.SumNumbers(&validGameIds)
 
-	fmt.Println("Valid Game IDs Sum: ", strconv.Itoa(idSum))
+	result := utility.NewResult(2, 1, "Valid Game IDs Sum", idSum)
+	result.AddDiagnostic("valid games: " + strconv.Itoa(len(validGameIds)) + " / " +
+		strconv.Itoa(len(*gamesPtr)))
+	return result
 }
 
-func solvePartTwo(input *[]string) {
-	fmt.Println("--- Solving Day Two - Part Two! ---")
-
-	// Build a list of Games
-	gamesPtr := buildGamesList(input)
+func solvePartTwo(gamesPtr *[]utility.Game) *utility.Result {
 	// Iterate through games collection and grab "powers"
 	gamePowers := make([]int, 0)
 	for _, gamePtr := range *gamesPtr {
 		gameInfo := *gamePtr.Info()
-		gamePower, err := strconv.Atoi(gameInfo["power"])
-		if err != nil {
-			panic(err)
-		}
+		// note: power is formatted from an int by Info(), so this can't fail
+		gamePower, _ := strconv.Atoi(gameInfo["power"])
 		gamePowers = append(gamePowers, gamePower)
 
 	}
 
 	// Calc game id sum
 	powerSum := utility.SumNumbers(&gamePowers)
-	fmt.Println("Sum of the powers of game sets: ", powerSum)
+	return utility.NewResult(2, 2, "Sum of the powers of game sets", powerSum)
 }
 
 /*
 Utility function builds a list of Game objects. Handles creating a Validator and
 PowerBehavior objects supporting the strategy pattern. Assumes a game consists of
-red, green, and blue cubes/dice
+red, green, and blue cubes/dice. Returns a ParseError for malformed game input
 */
-func buildGamesList(input *[]string) *[]utility.Game {
+func buildGamesList(input *[]string) (*[]utility.Game, error) {
 	// Build validator object
 	var validator utility.Validator
 	validator = rgbValidator{
@@ -233,23 +247,28 @@ func buildGamesList(input *[]string) *[]utility.Game {
 	powerBehavior = stdPowerBehavior{colors: []string{"red", "green", "blue"}}
 
 	// Build a list of Games and return
-	gamesPtr := parseGames(input, &validator, &powerBehavior)
-
-	return gamesPtr
+	return parseGames(input, &validator, &powerBehavior)
 }
 
 /*
 Iterate through game metadata input strings and build a list of Game objects
-that implement the utility.Game interface
+that implement the utility.Game interface. Returns a ParseError for the first line that
+can't be parsed
 */
 func parseGames(input *[]string, validator *utility.Validator,
-	powerBehavior *utility.PowerBehavior) *[]utility.Game {
+	powerBehavior *utility.PowerBehavior) (*[]utility.Game, error) {
 	// Parse a list of Games from input strings
 	games := make([]utility.Game, 0)
-	for _, inputStr := range *input {
+	for i, inputStr := range *input {
 		// grab id, rounds, and validator - init Game
-		gameId := parseGameId(inputStr)
-		gameRoundsPtr := parseRounds(inputStr)
+		gameId, err := parseGameId(inputStr)
+		if err != nil {
+			return nil, utility.AtLine(err, i+1)
+		}
+		gameRoundsPtr, err := parseRounds(inputStr)
+		if err != nil {
+			return nil, utility.AtLine(err, i+1)
+		}
 		game := rgbDiceGame{
 			gameId:    gameId,
 			games:     gameRoundsPtr,
@@ -259,49 +278,66 @@ func parseGames(input *[]string, validator *utility.Validator,
 		games = append(games, game)
 	}
 
-	return &games
+	return &games, nil
 }
 
-func parseGameId(inputStr string) string {
-	// Parse out Game \d*: from input str
-	idReg := regexp.MustCompile("Game \\d*:")
-	gameIdStr := idReg.FindString(inputStr)
-
-	// Parse digit from Game \d*: string
-	digitReg := regexp.MustCompile("\\d+")
-	idStr := digitReg.FindString(gameIdStr)
+// Parses the game id from a "Game <id>:" prefix. Returns a ParseError (without a line
+// number) if the prefix is missing or the id doesn't fit in an int
+func parseGameId(inputStr string) (int, error) {
+	// Parse out Game \d+: from input str - the submatch is the id digits
+	idReg := regexp.MustCompile("Game (\\d+):")
+	indices := idReg.FindStringSubmatchIndex(inputStr)
+	if indices == nil {
+		return 0, utility.NewParseError(0, 1, "missing \"Game <id>:\" prefix", nil)
+	}
 
-	return idStr // ex. '14' from 'Game 14:...' string
+	idStr := inputStr[indices[2]:indices[3]]
+	gameId, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, utility.NewParseError(0, indices[2]+1, "problem parsing game id", err)
+	}
+	return gameId, nil // ex. 14 from 'Game 14:...' string
 }
 
-func parseRounds(inputStr string) *[]utility.GameRound {
+func parseRounds(inputStr string) (*[]utility.GameRound, error) {
 	roundStrings := strings.Split(inputStr, "; ")
 
 	// parse rgbDiceRound objs from color and count from each round
 	rounds := make([]utility.GameRound, 0)
+	offset := 0 // track where each round starts in the line for error columns
 	for _, roundStr := range roundStrings {
 		// get rgbDiceRound from game round string - add to list
-		roundPtr := parseRound(roundStr)
+		roundPtr, err := parseRound(roundStr, offset)
+		if err != nil {
+			return nil, err
+		}
 		rounds = append(rounds, *roundPtr)
-
+		offset += len(roundStr) + len("; ")
 	}
 
 	// list of rgbDiceRounds pased - return
-	return &rounds
+	return &rounds, nil
 }
 
-func parseRound(roundStr string) *utility.GameRound {
+// Parses a single round of color counts. offset is the index of the round in the game
+// input line, used to report ParseError columns
+func parseRound(roundStr string, offset int) (*utility.GameRound, error) {
 	// Grab a list of rounds in a given game
-	colorCountPattern := "\\d+ (green|red|blue)"
+	colorCountPattern := "\\d+ [a-z]+"
 	colorCountReg := regexp.MustCompile(colorCountPattern)
-	colorCountStrings := colorCountReg.FindAllString(roundStr, -1)
+	colorCountIndices := colorCountReg.FindAllStringIndex(roundStr, -1)
 
 	// iterate trough color count string - build rgbDiceRound obj
 	roundColorMap := map[string]int{"red": 0, "green": 0, "blue": 0}
-	for _, colorCountStr := range colorCountStrings {
+	for _, indices := range colorCountIndices {
 		// grab qty and color details
+		colorCountStr := roundStr[indices[0]:indices[1]]
+		column := offset + indices[0] + 1
 		qtyColorTuple := strings.Split(colorCountStr, " ")
-		qty, _ := strconv.Atoi(qtyColorTuple[0])
+		qty, err := strconv.Atoi(qtyColorTuple[0])
+		if err != nil {
+			return nil, utility.NewParseError(0, column, "problem parsing color count", err)
+		}
 		color := qtyColorTuple[1]
 		switch color {
 		case "red":
@@ -311,7 +347,7 @@ func parseRound(roundStr string) *utility.GameRound {
 		case "blue":
 			roundColorMap["blue"] = qty
 		default:
-			panic("Error parsing color and qty")
+			return nil, utility.NewParseError(0, column, "unknown color \""+color+"\"", nil)
 		}
 
 	}
@@ -325,5 +361,5 @@ func parseRound(roundStr string) *utility.GameRound {
 	}
 
 	// rgbDiceRound populated - return it
-	return &round
+	return &round, nil
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Loads the example answers (the "_ex.txt" inputs) from data/answers.json, ordered by
+// day, part, then file name
+func exampleAnswers(tb testing.TB) (*answerBook, []answerKey) {
+	book, err := loadAnswers("data/answers.json")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	keys := make([]answerKey, 0)
+	for key := range book.answers {
+		if strings.HasSuffix(key.file, "_ex.txt") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		tb.Fatal("no example answers in data/answers.json")
+	}
+	slices.SortFunc(keys, func(a, b answerKey) int {
+		if a.day != b.day {
+			return cmp.Compare(a.day, b.day)
+		}
+		if a.part != b.part {
+			return cmp.Compare(a.part, b.part)
+		}
+		return cmp.Compare(a.file, b.file)
+	})
+	return book, keys
+}
+
+// Runs every registered day's Solver against its example file(s) and checks the answer
+// stored in data/answers.json
+func TestExamples(t *testing.T) {
+	book, keys := exampleAnswers(t)
+	for _, key := range keys {
+		key, want := key, book.answers[key]
+		inputPath := "data/" + key.file
+		t.Run(dayPartKey(key.day, key.part)+"/"+key.file, func(t *testing.T) {
+			solver, err := utility.LookupSolver(key.day)
+			if err != nil {
+				t.Fatal(err)
+			}
+			input, err := readInputFile(&inputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := solver.Solve(input, key.part)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Answer != want {
+				t.Errorf("%s = %d, want %d", result.Label, result.Answer, want)
+			}
+			if result.Day != key.day || result.Part != key.part {
+				t.Errorf("result for day %d part %d, want day %d part %d", result.Day,
+					result.Part, key.day, key.part)
+			}
+		})
+	}
+}
+
+// Every registered day and part should have at least one example answer
+func TestExamplesCoverSolvers(t *testing.T) {
+	covered := make(map[string]bool)
+	_, keys := exampleAnswers(t)
+	for _, key := range keys {
+		covered[dayPartKey(key.day, key.part)] = true
+	}
+	for _, solver := range utility.Solvers() {
+		for _, part := range solver.Parts() {
+			if !covered[dayPartKey(solver.Day(), part)] {
+				t.Errorf("no example answer for day %d part %d", solver.Day(), part)
+			}
+		}
+	}
+}
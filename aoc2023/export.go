@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Writes each job's input file through the day's exporter to stdout in the given format
+// (ex. dot or csv). Returns false if the day's Solver isn't an Exporter, the trace ids are
+// invalid, or a file can't be read, parsed, or exported.
+func exportJobs(solver utility.Solver, jobs []inputJob, format string, traceStr string) bool {
+	exporter, isExporter := solver.(utility.Exporter)
+	if !isExporter {
+		fmt.Fprintf(os.Stderr, "day %d doesn't have an input exporter\n", solver.Day())
+		return false
+	}
+	trace, err := parseIdList(traceStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+
+	ok := true
+	opts := utility.ExportOptions{Format: format, Trace: trace}
+	for _, job := range jobs {
+		inputPtr, err := readInputFile(&job.filepath)
+		if err == nil {
+			err = exporter.Export(os.Stdout, inputPtr, opts)
+			err = utility.InFile(err, inputName(job.filepath))
+		}
+		if err != nil {
+			// stdout carries the exported data - keep errors on stderr
+			fmt.Fprintln(os.Stderr, err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Parses a comma separated list of ids, ex. "79,14" -> [79 14]
+func parseIdList(idsStr string) ([]int, error) {
+	ids := make([]int, 0)
+	for _, idStr := range strings.Split(idsStr, ",") {
+		idStr = strings.TrimSpace(idStr)
+		if len(idStr) == 0 {
+			continue
+		}
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("trace id %q isn't a number", idStr)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
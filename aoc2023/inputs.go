@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Supported input kinds for resolving default input files in data/
+const (
+	inputExample = "example"
+	inputReal    = "real"
+)
+
+// Directory (relative to aoc2023) holding puzzle inputs
+const dataDir = "data"
+
+// Maps a day or part number to the word used in data/ file names, ex. 5 -> "five"
+var numberWords = map[int]string{
+	1: "one", 2: "two", 3: "three", 4: "four", 5: "five", 6: "six", 7: "seven",
+	8: "eight", 9: "nine", 10: "ten", 11: "eleven", 12: "twelve", 13: "thirteen",
+	14: "fourteen", 15: "fifteen", 16: "sixteen", 17: "seventeen", 18: "eighteen",
+	19: "nineteen", 20: "twenty", 21: "twenty_one", 22: "twenty_two",
+	23: "twenty_three", 24: "twenty_four", 25: "twenty_five",
+}
+
+// Infers the input file for a day and part from the data/ naming conventions:
+//
+//	real:    data/day_<day>_input.txt
+//	example: data/day_<day>_part_<part>_ex.txt if present, else data/day_<day>_ex.txt
+//
+// Returns an error for unknown input kinds or when no matching file exists.
+func resolveInputPath(day int, part int, kind string) (string, error) {
+	dayWord, found := numberWords[day]
+	if !found {
+		return "", fmt.Errorf("no input file naming for day %d", day)
+	}
+	prefix := filepath.Join(dataDir, "day_"+dayWord)
+
+	// build candidate paths in order of preference
+	var candidates []string
+	switch kind {
+	case inputReal:
+		candidates = []string{prefix + "_input.txt"}
+	case inputExample:
+		candidates = []string{prefix + "_ex.txt"}
+		if partWord, found := numberWords[part]; found {
+			candidates = append([]string{prefix + "_part_" + partWord + "_ex.txt"}, candidates...)
+		}
+	default:
+		return "", fmt.Errorf("input kind %q not supported: use example or real", kind)
+	}
+
+	// pick the first candidate that exists
+	for _, candidate := range candidates {
+		_, err := os.Stat(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no %s input for day %d part %d: tried %v", kind, day, part,
+		candidates)
+}
+
+// A set of parts to solve against one input file. The input is parsed once and shared
+// by every part.
+type inputJob struct {
+	filepath string
+	parts    []int
+}
+
+// Adds a part to the job for filepath, creating the job if needed. Job and part order
+// is preserved.
+func addPartToJobs(jobs []inputJob, filepath string, part int) []inputJob {
+	for i := range jobs {
+		if jobs[i].filepath == filepath {
+			jobs[i].parts = append(jobs[i].parts, part)
+			return jobs
+		}
+	}
+	return append(jobs, inputJob{filepath: filepath, parts: []int{part}})
+}
+
+// Creates one job per input file where every file is solved for all of the parts
+func newInputJobs(filepaths []string, parts []int) []inputJob {
+	jobs := make([]inputJob, len(filepaths))
+	for i, filepath := range filepaths {
+		jobs[i] = inputJob{filepath: filepath, parts: parts}
+	}
+	return jobs
+}
+
+// Resolves the default input file for each part and groups parts that share a file,
+// ex. day one examples have a file per part, while day two parts share one file
+func resolveInputJobs(day int, parts []int, kind string) ([]inputJob, error) {
+	jobs := make([]inputJob, 0)
+	for _, part := range parts {
+		filepath, err := resolveInputPath(day, part, kind)
+		if err != nil {
+			return nil, err
+		}
+		jobs = addPartToJobs(jobs, filepath, part)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+Machine-readable lint outcome for a single day and input file, emitted for -lint with
+-output=json and -output=ndjson
+*/
+type lintRecord struct {
+	Day    int      `json:"day"`
+	File   string   `json:"file"`
+	Lines  []string `json:"lines"`
+	Issues int      `json:"issues"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// Runs the day's input linter against each job's input file and prints the findings in
+// the selected output format. Returns false if the day's Solver isn't a Linter, a file
+// can't be read or parsed, or any issues were found.
+func lintJobs(solver utility.Solver, jobs []inputJob, format string) bool {
+	day := solver.Day()
+	linter, isLinter := solver.(utility.Linter)
+	if !isLinter {
+		fmt.Fprintf(messages, "day %d doesn't have an input linter\n", day)
+		return false
+	}
+
+	ok := true
+	records := make([]lintRecord, 0, len(jobs))
+	for _, job := range jobs {
+		record := lintRecord{Day: day, File: inputName(job.filepath), Lines: make([]string, 0)}
+		inputPtr, err := readInputFile(&job.filepath)
+		var report *utility.LintReport
+		if err == nil {
+			report, err = linter.Lint(inputPtr)
+			err = utility.InFile(err, inputName(job.filepath))
+		}
+		if err != nil {
+			record.Error = err.Error()
+			ok = false
+		} else {
+			record.Lines = report.Lines
+			record.Issues = report.Issues
+			ok = ok && report.Issues == 0
+		}
+		records = append(records, record)
+	}
+
+	if format == outputText {
+		for _, record := range records {
+			printLintRecord(&record)
+		}
+		return ok
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	if format == outputNdjson {
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				fmt.Fprintln(messages, err)
+				return false
+			}
+		}
+		return ok
+	}
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		fmt.Fprintln(messages, err)
+		return false
+	}
+	return ok
+}
+
+// Print the lint findings for a single input file in text format
+func printLintRecord(record *lintRecord) {
+	fmt.Println("--- Lint Day", record.Day, "-", record.File, "---")
+	if len(record.Error) > 0 {
+		fmt.Println(record.Error)
+		return
+	}
+	for _, line := range record.Lines {
+		fmt.Println(line)
+	}
+	fmt.Println("Issues found:", record.Issues)
+}
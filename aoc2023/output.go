@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Supported runner output formats
+const (
+	outputText   = "text"
+	outputJson   = "json"
+	outputNdjson = "ndjson"
+)
+
+// Parses and checks the -output flag value
+func parseOutputFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if !slices.Contains([]string{outputText, outputJson, outputNdjson}, format) {
+		return "", fmt.Errorf("output format %q not supported: use text, json, or ndjson",
+			format)
+	}
+	return format, nil
+}
+
+/*
+Machine-readable record for a single day, part, and input file. This is what the
+runner emits for -output=json and -output=ndjson.
+*/
+type outputRecord struct {
+	Day         int      `json:"day"`
+	Part        int      `json:"part"`
+	File        string   `json:"file"`
+	Label       string   `json:"label,omitempty"`
+	Answer      *int     `json:"answer"`
+	DurationNs  int64    `json:"duration_ns"`
+	Duration    string   `json:"duration"`
+	ParseNs     int64    `json:"parse_duration_ns"`
+	Parse       string   `json:"parse_duration"`
+	Diagnostics []string `json:"diagnostics"`
+	Warnings    []string `json:"warnings"`
+	Verify      string   `json:"verify,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// Converts a runSummary into an outputRecord. The answer is null when the part was
+// skipped or failed.
+func newOutputRecord(summary *runSummary) outputRecord {
+	record := outputRecord{
+		Day:         summary.day,
+		Part:        summary.part,
+		File:        summary.filepath,
+		Diagnostics: make([]string, 0),
+		Warnings:    summary.warnings,
+		Verify:      summary.status,
+	}
+	if record.Warnings == nil {
+		record.Warnings = make([]string, 0)
+	}
+	if summary.err != nil {
+		record.Error = summary.err.Error()
+	}
+	if summary.result != nil {
+		answer := summary.result.Answer
+		record.Label = summary.result.Label
+		record.Answer = &answer
+		record.DurationNs = summary.result.Elapsed.Nanoseconds()
+		record.ParseNs = summary.result.ParseElapsed.Nanoseconds()
+		record.Diagnostics = append(record.Diagnostics, summary.result.Diagnostics...)
+	}
+	record.Duration = time.Duration(record.DurationNs).String()
+	record.Parse = time.Duration(record.ParseNs).String()
+	return record
+}
+
+// Writes records as a single JSON array (json) or as one JSON object per line (ndjson)
+func writeRecords(writer io.Writer, summaries *[]runSummary, format string) error {
+	records := make([]outputRecord, len(*summaries))
+	for i := range *summaries {
+		records[i] = newOutputRecord(&(*summaries)[i])
+	}
+
+	encoder := json.NewEncoder(writer)
+	if format == outputNdjson {
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// Finds the Solver's reference implementation and narrows parts to those it covers. With
+// -part=all (all set), parts without a reference implementation are dropped, otherwise
+// they're an error - either way before any input is read.
+func referenceParts(solver utility.Solver, parts []int, all bool) (utility.Referencer, []int,
+	error) {
+	referencer, isReferencer := solver.(utility.Referencer)
+	if !isReferencer {
+		return nil, nil, fmt.Errorf("day %d doesn't have a reference implementation",
+			solver.Day())
+	}
+	narrowed := make([]int, 0, len(parts))
+	for _, part := range parts {
+		if slices.Contains(referencer.ReferenceParts(), part) {
+			narrowed = append(narrowed, part)
+		} else if !all {
+			return nil, nil, fmt.Errorf("day %d part %d doesn't have a reference implementation",
+				solver.Day(), part)
+		}
+	}
+	if len(narrowed) == 0 {
+		return nil, nil, fmt.Errorf("day %d doesn't have a reference implementation",
+			solver.Day())
+	}
+	return referencer, narrowed, nil
+}
+
+// Reads the job input file and solves each of the job's parts with the Solver's
+// reference implementation, logging progress at the info level. Returns a runSummary per
+// part - parts without a reference implementation get an error.
+func runReferenceJob(ctx context.Context, referencer utility.Referencer, day int, job inputJob,
+	workers int) []runSummary {
+	summaries := make([]runSummary, len(job.parts))
+	inputPtr, readErr := readInputFile(&job.filepath)
+	for i, part := range job.parts {
+		summary := runSummary{day: day, part: part, filepath: job.filepath, err: readErr}
+		if readErr == nil {
+			summary.result, summary.err = runReference(ctx, referencer, day, part, inputPtr,
+				workers)
+			summary.err = utility.InFile(summary.err, inputName(job.filepath))
+		}
+		summaries[i] = summary
+	}
+	return summaries
+}
+
+// Runs a single part's reference implementation and times it
+func runReference(ctx context.Context, referencer utility.Referencer, day int, part int,
+	input *[]string, workers int) (*utility.Result, error) {
+	if !slices.Contains(referencer.ReferenceParts(), part) {
+		return nil, fmt.Errorf("day %d part %d doesn't have a reference implementation", day,
+			part)
+	}
+	opts := utility.ReferenceOptions{Workers: workers, Progress: logProgress(day, part)}
+	start := time.Now()
+	result, err := referencer.Reference(ctx, input, part, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
+
+// Progress callback that logs each whole percent of work done, ex. "[INFO] day 5 part 2:
+// 1200 / 2400 chunks (50%)"
+func logProgress(day int, part int) func(done int, total int) {
+	lastPercent := -1
+	return func(done int, total int) {
+		percent := 100 * done / max(total, 1)
+		if percent == lastPercent {
+			return
+		}
+		lastPercent = percent
+		utility.Infof("day %d part %d: %d / %d chunks (%d%%)", day, part, done, total, percent)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+// -reference narrows -part=all to the parts with a reference implementation and rejects
+// an explicit part without one
+func TestReferenceParts(t *testing.T) {
+	dayFive, err := utility.LookupSolver(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, parts, err := referenceParts(dayFive, dayFive.Parts(), true); err != nil ||
+		!slices.Equal(parts, []int{2}) {
+		t.Errorf("day 5 -part=all: got %v, %v, want [2]", parts, err)
+	}
+	if _, _, err := referenceParts(dayFive, []int{1}, false); err == nil {
+		t.Error("day 5 part 1 should be an error")
+	}
+
+	dayFour, err := utility.LookupSolver(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := referenceParts(dayFour, dayFour.Parts(), true); err == nil {
+		t.Error("day 4 should be an error")
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
+)
+
+/*
+Data structure for the outcome of running a day and part against an input file. Holds
+either the Result or the error encountered trying to produce it, along with the answers
+file verification status and detail (empty when not verifying) and any warnings.
+repeated marks an error already reported for an earlier part of the same file, ex. the
+file couldn't be read or parsed.
+*/
+type runSummary struct {
+	day      int
+	part     int
+	filepath string
+	result   *utility.Result
+	err      error
+	status   string
+	detail   string
+	warnings []string
+	repeated bool
+}
+
+// Checks the summary Result against the answers file. Missing answers are also
+// reported as a warning.
+func (s *runSummary) verify(answers *answerBook) {
+	s.status, s.detail = answers.verify(s.result, s.filepath)
+	if s.status == verifyMissing {
+		s.warnings = append(s.warnings, s.detail)
+	}
+}
+
+// Runs every day and part of solvers against its default input of the given kind
+// (example or real), then prints a summary
+// table of answers and per-part timings (or records for structured output formats).
+// Day/part pairs in skip (ex. "5.2") are not run.
+// When verify is set, each answer is checked against the answers file, and when record
+// is set, each answer is stored in it. Returns false if any day or part failed to solve
+// or verify.
+func runAll(solvers []utility.Solver, kind string, skip []string, answers *answerBook,
+	verify bool, record bool, format string) bool {
+	summaries := make([]runSummary, 0)
+	start := time.Now()
+	for _, solver := range solvers {
+		// parts may have their own example input - group parts sharing an input file
+		jobs := make([]inputJob, 0)
+		for _, part := range solver.Parts() {
+			summary := runSummary{day: solver.Day(), part: part}
+			filepath, err := resolveInputPath(solver.Day(), part, kind)
+			if err != nil {
+				summary.err = err
+			} else if slices.Contains(skip, dayPartKey(solver.Day(), part)) {
+				summary.filepath = filepath
+				summary.warnings = append(summary.warnings, "skipped")
+			} else {
+				jobs = addPartToJobs(jobs, filepath, part)
+				continue
+			}
+			summaries = append(summaries, summary)
+		}
+		for _, job := range jobs {
+			summaries = append(summaries, runJob(solver, job)...)
+		}
+	}
+	elapsed := time.Since(start)
+
+	// order summaries by day and part for the table
+	slices.SortStableFunc(summaries, func(a, b runSummary) int {
+		if a.day != b.day {
+			return cmp.Compare(a.day, b.day)
+		}
+		return cmp.Compare(a.part, b.part)
+	})
+
+	// compare against and/or record into the answers file
+	for i := range summaries {
+		summary := &summaries[i]
+		if summary.result != nil && verify {
+			summary.verify(answers)
+		}
+		if summary.result != nil && record {
+			answers.record(summary.result, summary.filepath)
+		}
+	}
+
+	ok := summariesOk(&summaries)
+	if format == outputText {
+		printSummaryTable(&summaries, elapsed)
+	} else if err := writeRecords(os.Stdout, &summaries, format); err != nil {
+		fmt.Fprintln(messages, err)
+		return false
+	}
+	if record {
+		if err := answers.save(); err != nil {
+			fmt.Fprintln(messages, err)
+			return false
+		}
+		fmt.Fprintln(messages, "Recorded answers in", answers.path)
+	}
+	return ok
+}
+
+// Reads the job input file, parses it once, and solves each of the job's parts against
+// the shared parsed input. Returns a runSummary per part - read and parse errors are
+// reported for every part, marked repeated after the first.
+func runJob(solver utility.Solver, job inputJob) []runSummary {
+	summaries := make([]runSummary, len(job.parts))
+	for i, part := range job.parts {
+		summaries[i] = runSummary{day: solver.Day(), part: part, filepath: job.filepath}
+	}
+
+	inputPtr, err := readInputFile(&job.filepath)
+	var results []*utility.Result
+	if err == nil {
+		results, err = utility.SolveParts(solver, inputPtr, job.parts)
+		// parsers only see lines - point parse errors at the file they came from
+		err = utility.InFile(err, inputName(job.filepath))
+	}
+	for i := range summaries {
+		if i < len(results) {
+			summaries[i].result = results[i]
+		} else {
+			summaries[i].err = err
+			summaries[i].repeated = i > len(results)
+		}
+	}
+	return summaries
+}
+
+// Name used for an input file in messages - stdin doesn't have a path
+func inputName(filepath string) string {
+	if filepath == stdinPath {
+		return "<stdin>"
+	}
+	return filepath
+}
+
+// Formats a day and part as a "day.part" key, ex. "5.2"
+func dayPartKey(day int, part int) string {
+	return strconv.Itoa(day) + "." + strconv.Itoa(part)
+}
+
+// Splits a comma separated list of "day.part" keys, ex. "5.2,3.1"
+func parseSkipList(skipStr string) []string {
+	skip := make([]string, 0)
+	for _, key := range strings.Split(skipStr, ",") {
+		key = strings.TrimSpace(key)
+		if len(key) > 0 {
+			skip = append(skip, key)
+		}
+	}
+	return skip
+}
+
+// Returns false if any summary has an error or failed verification
+func summariesOk(summaries *[]runSummary) bool {
+	for _, summary := range *summaries {
+		if summary.err != nil || summary.status == verifyFail {
+			return false
+		}
+	}
+	return true
+}
+
+// Prints the run-all summary table
+func printSummaryTable(summaries *[]runSummary, elapsed time.Duration) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "DAY\tPART\tANSWER\tPARSE\tSOLVE\tVERIFY\tFILE\tLABEL")
+	for _, summary := range *summaries {
+		answer, parseStr, elapsedStr, label := "", "", "", ""
+		switch {
+		case summary.err != nil:
+			answer, label = "ERROR", summary.err.Error()
+		case summary.result == nil:
+			answer = "SKIPPED"
+		default:
+			answer = strconv.Itoa(summary.result.Answer)
+			parseStr = summary.result.ParseElapsed.String()
+			elapsedStr = summary.result.Elapsed.String()
+			label = summary.result.Label
+		}
+		fmt.Fprintf(writer, "%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n", summary.day, summary.part,
+			answer, parseStr, elapsedStr, summary.status, summary.filepath, label)
+	}
+	writer.Flush()
+	fmt.Printf("Total time elapsed: %s\n", elapsed)
+}
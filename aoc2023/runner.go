@@ -2,76 +2,450 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
-	"log"
+	"io"
 	"os"
+	"os/signal"
+	"runtime"
 	"strconv"
+	"strings"
 
-	"github.com/dswelbor/adventofcode/aoc2023/day_five"
-	"github.com/dswelbor/adventofcode/aoc2023/day_four"
-	"github.com/dswelbor/adventofcode/aoc2023/day_one"
-	"github.com/dswelbor/adventofcode/aoc2023/day_six"
-	"github.com/dswelbor/adventofcode/aoc2023/day_three"
-	"github.com/dswelbor/adventofcode/aoc2023/day_two"
+	// day_* packages register their Solvers with the registry at init time
+	_ "github.com/dswelbor/adventofcode/aoc2023/day_five"
+	_ "github.com/dswelbor/adventofcode/aoc2023/day_four"
+	_ "github.com/dswelbor/adventofcode/aoc2023/day_one"
+	_ "github.com/dswelbor/adventofcode/aoc2023/day_six"
+	_ "github.com/dswelbor/adventofcode/aoc2023/day_three"
+	_ "github.com/dswelbor/adventofcode/aoc2023/day_two"
+	"github.com/dswelbor/adventofcode/aoc2023/utility"
 )
 
 func main() {
 	// Grab cli args and parse
 	dayPtr := flag.Int("day", 1, "problem day number")
-	filepathPtr := flag.String("file", "data/day_one_part_one_ex.txt", "relative filtepath to input")
-	partPtr := flag.Int("part", 1, "problem part: ex. -part=1 or -part=2")
+	filepathPtr := flag.String("file", "",
+		"comma separated relative filtepaths to input, or - to read stdin (default: inferred from -day, -part, and -input)")
+	inputKindPtr := flag.String("input", "",
+		"default input kind when -file is not set: example or real (default: example, or real with -all)")
+	partPtr := flag.String("part", partAll, "problem part: ex. -part=1, -part=2, or -part=all")
+	listPtr := flag.Bool("list", false, "list registered days and parts, then exit")
+	allPtr := flag.Bool("all", false, "run every registered day and part against its default input")
+	skipPtr := flag.String("skip", "", "comma separated day.part pairs to skip with -all: ex. -skip=4.2")
+	verifyPtr := flag.Bool("verify", false, "verify answers against the answers file: PASS/FAIL/MISSING")
+	recordPtr := flag.Bool("record", false, "record computed answers into the answers file")
+	answersPtr := flag.String("answers", "data/answers.json", "relative filepath to the answers file")
+	benchPtr := flag.Bool("bench", false, "benchmark the day and part instead of solving once")
+	runsPtr := flag.Int("runs", 10, "number of runs for -bench")
+	referencePtr := flag.Bool("reference", false,
+		"solve with the part's reference implementation (ex. day 5 part 2 brute force)")
+	workersPtr := flag.Int("workers", runtime.NumCPU(), "worker goroutines for -reference")
+	exportPtr := flag.String("export", "",
+		"write the day's parsed input for inspection instead of solving: ex. table or csv (day 4), dot or csv (day 5)")
+	tracePtr := flag.String("trace", "", "comma separated ids to trace with -export: ex. -trace=79,14")
+	lintPtr := flag.Bool("lint", false, "check the day's input for problems instead of solving (day 5)")
+	outputPtr := flag.String("output", outputText, "output format: text, json, or ndjson")
+	settings := make(map[string]string)
+	flag.Func("set", "change a solver setting: ex. -set win-behavior.2=cards:cap=2 (repeatable, see -list)",
+		func(assignment string) error {
+			name, value, err := utility.ParseSetting(assignment)
+			settings[name] = value
+			return err
+		})
+	logLevelPtr := flag.String("log-level", utility.LevelWarn.String(),
+		"stderr log level: error, warn, info, debug, or trace")
+	verbosePtr := flag.Bool("v", false, "verbose logging: same as -log-level=debug")
 	flag.Parse()
 
-	// print cli args
-	fmt.Println("day:", *dayPtr)
-	fmt.Println("part: ", *partPtr)
-	fmt.Println("file:", *filepathPtr)
-	inputPtr := readInputFile(filepathPtr)
-
-	// Execute day (and part) by passed cli args
-	switch *dayPtr {
-	case 1:
-		day_one.SolveDayOne(inputPtr, *partPtr)
-	case 2:
-		day_two.SolveDayTwo(inputPtr, *partPtr)
-	case 3:
-		day_three.SolveDayThree(inputPtr, *partPtr)
-	case 4:
-		day_four.SolveDayFour(inputPtr, *partPtr)
-	case 5:
-		day_five.SolveDayFive(inputPtr, *partPtr)
-	case 6:
-		day_six.SolveDaySix(inputPtr, *partPtr)
-	default:
-		fmt.Println("Day: " + strconv.Itoa(*dayPtr) + " not implemented")
+	// set up logging - log messages go to stderr so stdout only carries results
+	logLevel, err := utility.ParseLogLevel(*logLevelPtr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *verbosePtr && logLevel < utility.LevelDebug {
+		logLevel = utility.LevelDebug
+	}
+	utility.SetLogLevel(logLevel)
+
+	// check output format - structured formats keep stdout for records only
+	format, err := parseOutputFormat(*outputPtr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if format != outputText {
+		messages = os.Stderr
+	}
+
+	// load accepted answers when verifying or recording
+	var answers *answerBook
+	if *verifyPtr || *recordPtr {
+		answers, err = loadAnswers(*answersPtr)
+		if err != nil {
+			fmt.Fprintln(messages, err)
+			os.Exit(1)
+		}
+	}
+
+	// list registered solvers and exit
+	if *listPtr {
+		listSolvers()
+		return
+	}
+
+	// run every day and part, then print the summary
+	if *allPtr {
+		if *inputKindPtr == "" {
+			*inputKindPtr = inputReal
+		}
+		solvers, err := configureSolvers(utility.Solvers(), settings)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !runAll(solvers, *inputKindPtr, parseSkipList(*skipPtr), answers, *verifyPtr,
+			*recordPtr, format) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Look up the Solver for the day by passed cli args
+	solver, err := utility.LookupSolver(*dayPtr)
+	if err != nil {
+		fmt.Fprintln(messages, err)
+		listSolvers()
+		os.Exit(1)
+	}
+	solvers, err := configureSolvers([]utility.Solver{solver}, settings)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	solver = solvers[0]
+	parts, err := parsePartList(*partPtr, solver)
+	if err != nil {
+		fmt.Fprintln(messages, err)
+		os.Exit(1)
+	}
+	// only parts with a reference implementation can run with -reference
+	var referencer utility.Referencer
+	if *referencePtr {
+		referencer, parts, err = referenceParts(solver, parts,
+			strings.ToLower(*partPtr) == partAll)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if *inputKindPtr == "" {
+		*inputKindPtr = inputExample
+	}
+	var jobs []inputJob
+	if filepaths := parseFileList(*filepathPtr); len(filepaths) > 0 {
+		jobs = newInputJobs(filepaths, parts)
+	} else {
+		// no -file passed - infer the input file from the day, part, and input kind
+		jobs, err = resolveInputJobs(*dayPtr, parts, *inputKindPtr)
+		if err != nil {
+			fmt.Fprintln(messages, err)
+			os.Exit(1)
+		}
+	}
+
+	// export each input file's parsed model
+	if len(*exportPtr) > 0 {
+		if !exportJobs(solver, jobs, *exportPtr, *tracePtr) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// lint each input file and print the findings
+	if *lintPtr {
+		if !lintJobs(solver, jobs, format) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// benchmark the parts against each input file and print statistics
+	if *benchPtr {
+		if !benchJobs(solver, jobs, *runsPtr, format) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// log cli args and execute the parts against each input file
+	utility.Infof("day: %d", *dayPtr)
+	utility.Infof("part: %s", *partPtr)
+	utility.Infof("file: %s", jobFilepaths(jobs))
+	run := func(job inputJob) []runSummary { return runJob(solver, job) }
+	if *referencePtr {
+		// cancel long reference runs cleanly on ctrl-c
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		run = func(job inputJob) []runSummary {
+			return runReferenceJob(ctx, referencer, *dayPtr, job, *workersPtr)
+		}
+	}
+	if !runJobs(run, jobs, answers, *verifyPtr, *recordPtr, format) {
+		os.Exit(1)
 	}
 }
 
-// Pass in a pointer to file path, read the file by line, and return slice of strings
-func readInputFile(filepathPtr *string) *[]string {
-	fileStrings := make([]string, 0)
+// Value for -part that runs every part a Solver supports
+const partAll = "all"
 
-	f, err := os.Open(*filepathPtr)
+// Parses the -part flag into a list of parts, ex. "all" -> [1 2], "2" -> [2]
+func parsePartList(partStr string, solver utility.Solver) ([]int, error) {
+	if strings.ToLower(partStr) == partAll {
+		return solver.Parts(), nil
+	}
+	part, err := strconv.Atoi(partStr)
+	if err != nil {
+		return nil, fmt.Errorf("part %q not supported: use a part number or all", partStr)
+	}
+	return []int{part}, nil
+}
 
+// Comma separated list of the input files for jobs
+func jobFilepaths(jobs []inputJob) string {
+	filepaths := make([]string, len(jobs))
+	for i, job := range jobs {
+		filepaths[i] = job.filepath
+	}
+	return strings.Join(filepaths, ",")
+}
+
+// Function signature for running a single job's parts, ex. with a day's Solver
+type jobRunner func(job inputJob) []runSummary
+
+// Runs each job's parts against its input file and prints a result per file and part in
+// the selected output format. Unreadable files are reported without stopping the
+// remaining files. Returns false if any file failed to read, solve, or verify.
+func runJobs(run jobRunner, jobs []inputJob, answers *answerBook, verify bool,
+	record bool, format string) bool {
+	summaries := make([]runSummary, 0)
+	ok := true
+	for _, job := range jobs {
+		jobSummaries := run(job)
+		for i := range jobSummaries {
+			summary := &jobSummaries[i]
+			// compare against and/or record into the answers file
+			if summary.err != nil {
+				ok = false
+			} else if answers != nil {
+				ok = checkAnswer(answers, summary, verify, record) && ok
+			}
+		}
+		summaries = append(summaries, jobSummaries...)
+	}
+
+	// print the results in the selected output format
+	var err error
+	if format != outputText {
+		err = writeRecords(os.Stdout, &summaries, format)
+	} else {
+		for _, summary := range summaries {
+			// a file's read or parse error is printed once, not for every part
+			if !summary.repeated {
+				printSummary(&summary, len(jobs) > 1)
+			}
+		}
+	}
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(messages, err)
+		return false
+	}
+	return ok
+}
+
+// Benchmarks each job's parts against its input file and prints statistics per file and
+// part. json output is collected into a single array written after every job. Returns
+// false if any file failed to read or solve.
+func benchJobs(solver utility.Solver, jobs []inputJob, runs int, format string) bool {
+	allStats := make([]*benchStats, 0)
+	ok := true
+	for _, job := range jobs {
+		inputPtr, err := readInputFile(&job.filepath)
+		for _, part := range job.parts {
+			if err == nil {
+				var stats *benchStats
+				stats, err = runBench(solver, inputPtr, part, runs, job.filepath)
+				err = utility.InFile(err, inputName(job.filepath))
+				if err == nil && format == outputJson {
+					allStats = append(allStats, stats)
+				} else if err == nil {
+					err = printBenchStats(stats, format)
+				}
+			}
+			if err != nil {
+				fmt.Fprintln(messages, err)
+				ok = false
+				break
+			}
+		}
+	}
+	if format == outputJson {
+		if err := writeBenchStats(allStats); err != nil {
+			fmt.Fprintln(messages, err)
+			return false
+		}
+	}
+	return ok
+}
+
+// Print the outcome of running a part against an input file in text format. The file
+// name is included when results for several files are printed. Errors go to stderr.
+func printSummary(summary *runSummary, showFile bool) {
+	if showFile {
+		fmt.Println("=== file:", summary.filepath, "===")
+	}
+	if summary.err != nil {
+		fmt.Fprintln(os.Stderr, summary.err)
+		return
+	}
+	printResult(summary.result)
+	if summary.status != "" {
+		fmt.Println("Verify:", summary.status, "-", summary.detail)
 	}
+}
+
+// File path that reads puzzle input from stdin
+const stdinPath = "-"
+
+// Destination for runner status messages. Switches to stderr for structured output
+// formats so stdout only carries records.
+var messages io.Writer = os.Stdout
+
+// Verifies and/or records a solved runSummary in the answers file. Returns false on a
+// verification mismatch or when the answers file can't be saved.
+func checkAnswer(answers *answerBook, summary *runSummary, verify bool, record bool) bool {
+	ok := true
+	if verify {
+		summary.verify(answers)
+		ok = summary.status != verifyFail
+	}
+	if record {
+		answers.record(summary.result, summary.filepath)
+		if err := answers.save(); err != nil {
+			fmt.Fprintln(messages, err)
+			return false
+		}
+		fmt.Fprintln(messages, "Recorded answer", summary.result.Answer, "in", answers.path)
+	}
+	return ok
+}
+
+// Print a solved Result: header, labeled answer, diagnostics, and elapsed parse and
+// solve times
+func printResult(result *utility.Result) {
+	fmt.Println("--- Solved Day", result.Day, "- Part", result.Part, "---")
+	fmt.Println(result)
+	for _, diagnostic := range result.Diagnostics {
+		fmt.Println("  " + diagnostic)
+	}
+	fmt.Printf("Parse time elapsed: %s\n", result.ParseElapsed)
+	fmt.Printf("Solve time elapsed: %s\n", result.Elapsed)
+}
 
+// Print each registered day along with its supported parts and settings
+func listSolvers() {
+	fmt.Fprintln(messages, "Implemented days:")
+	for _, solver := range utility.Solvers() {
+		fmt.Fprintln(messages, "  day:", solver.Day(), "parts:", solver.Parts())
+	}
+	fmt.Fprintln(messages, "Settings (-set name=value):")
+	for _, solver := range utility.Solvers() {
+		if configurable, isConfigurable := solver.(utility.Configurable); isConfigurable {
+			for _, setting := range configurable.Settings() {
+				fmt.Fprintf(messages, "  day %d %s: %s\n", solver.Day(), setting.Name,
+					setting.Usage)
+			}
+		}
+	}
+}
+
+// Configures each Solver with the -set settings it declares. Returns the Solvers in the
+// same order, or an error if a setting isn't declared by any of them or has a bad value.
+func configureSolvers(solvers []utility.Solver, settings map[string]string) ([]utility.Solver,
+	error) {
+	configured := make([]utility.Solver, len(solvers))
+	used := make(map[string]bool)
+	for i, solver := range solvers {
+		configured[i] = solver
+		configurable, isConfigurable := solver.(utility.Configurable)
+		if !isConfigurable {
+			continue
+		}
+		// only pass the settings the Solver declares
+		values := make(map[string]string)
+		for _, setting := range configurable.Settings() {
+			if value, found := settings[setting.Name]; found {
+				values[setting.Name] = value
+				used[setting.Name] = true
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		var err error
+		configured[i], err = configurable.Configure(values)
+		if err != nil {
+			return nil, fmt.Errorf("day %d: %w", solver.Day(), err)
+		}
+	}
+	for name := range settings {
+		if !used[name] {
+			return nil, fmt.Errorf("unknown setting %q: see -list", name)
+		}
+	}
+	return configured, nil
+}
+
+// Splits a comma separated list of input file paths, ex. "data/day_six_ex.txt,-"
+func parseFileList(filepathsStr string) []string {
+	filepaths := make([]string, 0)
+	for _, filepath := range strings.Split(filepathsStr, ",") {
+		filepath = strings.TrimSpace(filepath)
+		if len(filepath) > 0 {
+			filepaths = append(filepaths, filepath)
+		}
+	}
+	return filepaths
+}
+
+// Pass in a pointer to file path, read the file by line, and return slice of strings.
+// A file path of "-" reads from stdin.
+func readInputFile(filepathPtr *string) (*[]string, error) {
+	if *filepathPtr == stdinPath {
+		return readInput(os.Stdin)
+	}
+
+	f, err := os.Open(*filepathPtr)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	return readInput(f)
+}
 
+// Read input by line and return slice of strings
+func readInput(reader io.Reader) (*[]string, error) {
+	fileStrings := make([]string, 0)
+	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
-
-		// fmt.Println(scanner.Text())
 		fileStrings = append(fileStrings, scanner.Text())
-
 	}
 
 	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	return &fileStrings
+	return &fileStrings, nil
 }
@@ -0,0 +1,24 @@
+package utility
+
+import (
+	"io"
+)
+
+/*
+Settings for exporting a day's parsed input for inspection: the output format (ex. "dot"
+or "csv") and optional ids to trace through the model (ex. seed ids)
+*/
+type ExportOptions struct {
+	Format string
+	Trace  []int
+}
+
+/*
+Optional interface for a Solver that can export its day's parsed input. The runner finds
+it with a type assertion on the registered Solver. Export writes the parsed input to
+writer in opts.Format, and returns an error for unsupported formats or input that can't
+be parsed.
+*/
+type Exporter interface {
+	Export(writer io.Writer, input *[]string, opts ExportOptions) error
+}
@@ -0,0 +1,41 @@
+package utility
+
+import (
+	"fmt"
+)
+
+/*
+Outcome of checking a day's input for problems that don't stop it from parsing, ex.
+overlapping ranges. Lines are human readable findings and statistics, and Issues counts
+the findings that are problems.
+*/
+type LintReport struct {
+	Day    int
+	Lines  []string
+	Issues int
+}
+
+// Constructor creates an empty LintReport for a day
+func NewLintReport(day int) *LintReport {
+	return &LintReport{Day: day, Lines: make([]string, 0)}
+}
+
+// Adds an informational line to the report
+func (r *LintReport) AddInfo(format string, args ...any) {
+	r.Lines = append(r.Lines, fmt.Sprintf(format, args...))
+}
+
+// Adds a problem to the report. Problem lines are indented under the info line before.
+func (r *LintReport) AddIssue(format string, args ...any) {
+	r.Lines = append(r.Lines, "  "+fmt.Sprintf(format, args...))
+	r.Issues++
+}
+
+/*
+Optional interface for a Solver that can check its day's input for problems. The runner
+finds it with a type assertion on the registered Solver. Lint returns an error if the
+input can't be parsed at all.
+*/
+type Linter interface {
+	Lint(input *[]string) (*LintReport, error)
+}
@@ -0,0 +1,123 @@
+package utility
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+/*
+Verbosity level for log messages. Lower levels are more important: a logger set to
+LevelInfo writes error, warn, and info messages and drops debug and trace messages.
+*/
+type LogLevel int
+
+// Supported log levels, from least to most verbose
+const (
+	LevelError LogLevel = iota
+	LevelWarn
+	LevelInfo
+	LevelDebug
+	LevelTrace
+)
+
+// Level names used for parsing and for message prefixes, ex. "[INFO]"
+var logLevelNames = []string{"error", "warn", "info", "debug", "trace"}
+
+// Returns the lower case level name, ex. "debug"
+func (l LogLevel) String() string {
+	if l < LevelError || l > LevelTrace {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return logLevelNames[l]
+}
+
+// Parses a level name (case insensitive), ex. "debug" -> LevelDebug
+func ParseLogLevel(levelStr string) (LogLevel, error) {
+	levelStr = strings.ToLower(strings.TrimSpace(levelStr))
+	for i, name := range logLevelNames {
+		if levelStr == name {
+			return LogLevel(i), nil
+		}
+	}
+	return LevelError, fmt.Errorf("log level %q not supported: use %s", levelStr,
+		strings.Join(logLevelNames, ", "))
+}
+
+/*
+Shared leveled logger for solvers and the runner. Messages go to stderr by default so
+stdout only carries results. The package level functions (Errorf, Infof, ...) write to
+this logger and are safe to call from multiple goroutines.
+*/
+type logger struct {
+	mu     sync.Mutex
+	level  LogLevel
+	output io.Writer
+}
+
+var std = &logger{level: LevelWarn, output: os.Stderr}
+
+// Sets the most verbose level that gets written. Defaults to LevelWarn.
+func SetLogLevel(level LogLevel) {
+	std.mu.Lock()
+	defer std.mu.Unlock()
+	std.level = level
+}
+
+// Returns the current log level
+func GetLogLevel() LogLevel {
+	std.mu.Lock()
+	defer std.mu.Unlock()
+	return std.level
+}
+
+// Sets where log messages are written. Defaults to stderr.
+func SetLogOutput(output io.Writer) {
+	std.mu.Lock()
+	defer std.mu.Unlock()
+	std.output = output
+}
+
+// Reports whether messages at level would be written. Useful to skip building
+// expensive log messages.
+func LogEnabled(level LogLevel) bool {
+	return level <= GetLogLevel()
+}
+
+// Writes a single message line prefixed with its level, ex. "[INFO] built 7 translators"
+func (l *logger) logf(level LogLevel, format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level > l.level {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	fmt.Fprintf(l.output, "[%s] %s\n", strings.ToUpper(level.String()), strings.TrimSuffix(msg, "\n"))
+}
+
+// Logs a message at LevelError
+func Errorf(format string, args ...any) {
+	std.logf(LevelError, format, args...)
+}
+
+// Logs a message at LevelWarn
+func Warnf(format string, args ...any) {
+	std.logf(LevelWarn, format, args...)
+}
+
+// Logs a message at LevelInfo
+func Infof(format string, args ...any) {
+	std.logf(LevelInfo, format, args...)
+}
+
+// Logs a message at LevelDebug
+func Debugf(format string, args ...any) {
+	std.logf(LevelDebug, format, args...)
+}
+
+// Logs a message at LevelTrace
+func Tracef(format string, args ...any) {
+	std.logf(LevelTrace, format, args...)
+}
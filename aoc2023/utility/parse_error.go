@@ -0,0 +1,74 @@
+package utility
+
+import (
+	"errors"
+	"strconv"
+)
+
+/*
+Uniform error type for problems parsing puzzle input. Carries where the problem was
+found (input file, 1-based line and column) and a message. Line and column are 0 when
+unknown, and File is filled in by the runner since parsers only see input lines.
+*/
+type ParseError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+	Err     error
+}
+
+// Constructor creates a ParseError for a line and column with an optional underlying error
+func NewParseError(line int, column int, message string, err error) *ParseError {
+	return &ParseError{Line: line, Column: column, Message: message, Err: err}
+}
+
+// Formats the error like a compiler diagnostic, ex. "data/day_two_ex.txt:3:7: message"
+func (e *ParseError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location += ":" + strconv.Itoa(e.Line)
+		if e.Column > 0 {
+			location += ":" + strconv.Itoa(e.Column)
+		}
+	}
+
+	msg := e.Message
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	if len(location) > 0 {
+		return location + ": " + msg
+	}
+	return msg
+}
+
+// Returns the underlying error so errors.Is() and errors.As() can inspect it
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Attaches a 1-based line number to a parse error that doesn't have one yet. Errors that
+// aren't a ParseError are wrapped in one. Returns nil for a nil error.
+func AtLine(err error, line int) error {
+	if err == nil {
+		return nil
+	}
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		if parseErr.Line == 0 {
+			parseErr.Line = line
+		}
+		return parseErr
+	}
+	return NewParseError(line, 0, "invalid input", err)
+}
+
+// Attaches the input file name to a ParseError. Other errors are returned unchanged.
+func InFile(err error, file string) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && len(parseErr.File) == 0 {
+		parseErr.File = file
+	}
+	return err
+}
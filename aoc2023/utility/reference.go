@@ -0,0 +1,26 @@
+package utility
+
+import (
+	"context"
+)
+
+/*
+Settings for running a reference implementation: how many goroutines it may use, and an
+optional callback for reporting progress as units of work (ex. chunks of seed ids)
+finish. Progress is called from a single goroutine.
+*/
+type ReferenceOptions struct {
+	Workers  int
+	Progress func(done int, total int)
+}
+
+/*
+Optional interface for a Solver with reference implementations of some of its parts: slow
+but simple solutions (ex. brute force) used to cross-check the regular solution. The
+runner finds it with a type assertion on the registered Solver. Reference should stop
+early and return ctx.Err() when ctx is cancelled.
+*/
+type Referencer interface {
+	ReferenceParts() []int
+	Reference(ctx context.Context, input *[]string, part int, opts ReferenceOptions) (*Result, error)
+}
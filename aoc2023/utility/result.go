@@ -0,0 +1,45 @@
+package utility
+
+import (
+	"strconv"
+	"time"
+)
+
+/*
+Data structure for the outcome of solving a single day and part including:
+- the day and part that were solved
+- a human readable label describing the answer
+- the answer value itself
+- how long solving the part took to run, and how long parsing the (shared) input took
+- optional diagnostics (supporting details that aren't the answer)
+*/
+type Result struct {
+	Day          int
+	Part         int
+	Label        string
+	Answer       int
+	Elapsed      time.Duration
+	ParseElapsed time.Duration
+	Diagnostics  []string
+}
+
+// Constructor creates a Result for a part with a labeled answer
+func NewResult(day int, part int, label string, answer int) *Result {
+	return &Result{
+		Day:         day,
+		Part:        part,
+		Label:       label,
+		Answer:      answer,
+		Diagnostics: make([]string, 0),
+	}
+}
+
+// Adds a supporting detail to the Result
+func (r *Result) AddDiagnostic(diagnostic string) {
+	r.Diagnostics = append(r.Diagnostics, diagnostic)
+}
+
+// Formats the labeled answer, ex. "Calibration Number Sum: 142"
+func (r *Result) String() string {
+	return r.Label + ": " + strconv.Itoa(r.Answer)
+}
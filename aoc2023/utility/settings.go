@@ -0,0 +1,35 @@
+package utility
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+A named solver setting that the runner can change with -set name=value, ex. day four's
+overflow policy
+*/
+type Setting struct {
+	Name  string
+	Usage string
+}
+
+/*
+Optional interface for a Solver with settings. The runner finds it with a type assertion
+on the registered Solver. Configure returns a new Solver that parses and solves with the
+settings (name: value), leaving the registered Solver unchanged. Returns an error for
+unknown settings or bad values.
+*/
+type Configurable interface {
+	Settings() []Setting
+	Configure(settings map[string]string) (Solver, error)
+}
+
+// Splits a "name=value" setting assignment into its trimmed name and value
+func ParseSetting(assignment string) (string, string, error) {
+	name, value, found := strings.Cut(assignment, "=")
+	if !found {
+		return "", "", fmt.Errorf("setting %q should look like name=value", assignment)
+	}
+	return strings.TrimSpace(name), strings.TrimSpace(value), nil
+}
@@ -0,0 +1,142 @@
+package utility
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+/*
+Common interface for a day's puzzle solution. Each day_* package registers a Solver
+with the central registry at init time so the runner can look up, list, and execute
+solutions without a hard-coded switch on the day number. Parse builds the day's model
+from input once, so every part can be solved against the same parsed Puzzle.
+*/
+type Solver interface {
+	Day() int
+	Parts() []int
+	Parse(input *[]string) (Puzzle, error)
+	Solve(input *[]string, part int) (*Result, error)
+}
+
+/*
+Common interface for a day's parsed input model. Each part is solved against the same
+Puzzle, so parsing work is shared between parts.
+*/
+type Puzzle interface {
+	Solve(part int) (*Result, error)
+}
+
+// Function signature for the ParseDayX entry points wrapped by a Solver
+type ParseFunc func(input *[]string) (Puzzle, error)
+
+/*
+Solver concretion that wraps a ParseDayX entry point function along with the day
+number and list of supported parts.
+*/
+type funcSolver struct {
+	day   int
+	parts []int
+	parse ParseFunc
+}
+
+// Constructor creates a Solver from a day number, supported parts, and parse function
+func NewSolver(day int, parts []int, parse ParseFunc) Solver {
+	return &funcSolver{day: day, parts: parts, parse: parse}
+}
+
+// simple accessor function that returns the Solver day number
+func (s *funcSolver) Day() int {
+	return s.day
+}
+
+// simple accessor function that returns the list of supported parts
+func (s *funcSolver) Parts() []int {
+	return s.parts
+}
+
+// Solver.Parse() implementation: delegates to the wrapped parse function
+func (s *funcSolver) Parse(input *[]string) (Puzzle, error) {
+	return s.parse(input)
+}
+
+// Solver.Solve() implementation: parses the input and solves a single part
+func (s *funcSolver) Solve(input *[]string, part int) (*Result, error) {
+	results, err := SolveParts(s, input, []int{part})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// Parses the input once and solves each part against the shared Puzzle. Each Result
+// records the shared parse time separately from its own solve time. On error, the
+// Results for parts solved before the failing part are returned along with the error.
+func SolveParts(solver Solver, input *[]string, parts []int) ([]*Result, error) {
+	// check every part is supported before doing any work
+	for _, part := range parts {
+		if !slices.Contains(solver.Parts(), part) {
+			return nil, fmt.Errorf("day %d part %d not implemented", solver.Day(), part)
+		}
+	}
+
+	// parse the input once
+	start := time.Now()
+	puzzle, err := solver.Parse(input)
+	parseElapsed := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	// solve each part against the shared puzzle
+	results := make([]*Result, 0, len(parts))
+	for _, part := range parts {
+		start = time.Now()
+		result, err := puzzle.Solve(part)
+		if err != nil {
+			return results, err
+		}
+		result.Elapsed = time.Since(start)
+		result.ParseElapsed = parseElapsed
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// central registry of Solvers keyed by day number
+var solverRegistry = make(map[int]Solver)
+
+// Adds a Solver to the registry. This is intended to be called from a day_* package
+// init() function. Registering the same day twice is a programming error and panics.
+func RegisterSolver(solver Solver) {
+	day := solver.Day()
+	if _, found := solverRegistry[day]; found {
+		panic("Solver for day " + strconv.Itoa(day) + " already registered")
+	}
+	solverRegistry[day] = solver
+}
+
+// Fetches the registered Solver for a day. Returns an error if no Solver is registered
+func LookupSolver(day int) (Solver, error) {
+	solver, found := solverRegistry[day]
+	if !found {
+		return nil, fmt.Errorf("day %d not implemented", day)
+	}
+	return solver, nil
+}
+
+// Returns a list of all registered Solvers ordered by day number
+func Solvers() []Solver {
+	days := make([]int, 0, len(solverRegistry))
+	for day := range solverRegistry {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+
+	solvers := make([]Solver, len(days))
+	for i, day := range days {
+		solvers[i] = solverRegistry[day]
+	}
+	return solvers
+}
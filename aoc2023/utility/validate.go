@@ -1,8 +1,9 @@
 package utility
 
 import (
-	"fmt"
+	"regexp"
 	"strconv"
+	"unicode"
 )
 
 type Validator interface {
@@ -35,18 +36,55 @@ func ListContainsString(items *[]string, searchTerm string) bool {
 	return false
 }
 
-func ListAtoi(numStrings *[]string) *[]int {
+// Parses a list of number strings into a list of ints. columns optionally holds the
+// 1-based input column of each number string. Returns a ParseError (without a line
+// number) at the column of the first string that isn't a valid int
+func ListAtoi(numStrings *[]string, columns *[]int) (*[]int, error) {
 	numbers := make([]int, len(*numStrings)) // init list of ints
 
 	for i, numStr := range *numStrings {
 		num, err := strconv.Atoi(numStr)
 		if err != nil {
-			fmt.Println("[ERROR] Problem parsing number string: ", numStr,
-				" into an int")
-			panic(err) // TODO: Implement a better way to handle this
+			column := 0
+			if columns != nil && i < len(*columns) {
+				column = (*columns)[i]
+			}
+			return nil, NewParseError(0, column, "problem parsing number string into an int",
+				err)
 		}
 		// add number to list
 		numbers[i] = num
 	}
-	return &numbers
+	return &numbers, nil
+}
+
+// Splits a string on whitespace like strings.Fields, along with the 1-based column of
+// each field. offset is the number of input columns before the string, ex. when it's
+// the part of a line after a ":"
+func FieldsWithColumns(s string, offset int) ([]string, []int) {
+	fields := make([]string, 0)
+	columns := make([]int, 0)
+	start := -1
+	for i, char := range s + " " {
+		if !unicode.IsSpace(char) && start < 0 {
+			start = i
+		} else if unicode.IsSpace(char) && start >= 0 {
+			fields = append(fields, s[start:i])
+			columns = append(columns, offset+start+1)
+			start = -1
+		}
+	}
+	return fields, columns
+}
+
+// Finds every match of reg in s like FindAllString, along with the 1-based column of
+// each match
+func FindAllWithColumns(reg *regexp.Regexp, s string) ([]string, []int) {
+	matches := make([]string, 0)
+	columns := make([]int, 0)
+	for _, indices := range reg.FindAllStringIndex(s, -1) {
+		matches = append(matches, s[indices[0]:indices[1]])
+		columns = append(columns, indices[0]+1)
+	}
+	return matches, columns
 }